@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"solana/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags set from profile keys
+var profileFlags = map[string]string{
+	"cluster":           "cluster",
+	"rpc_url":           "rpc-url",
+	"ws_url":            "ws-url",
+	"backup_rpc_url":    "backup-rpc-url",
+	"keypair":           "keypair",
+	"wallet":            "wallet",
+	"commitment":        "commitment",
+	"poll_confirmation": "poll-confirmation",
+	"rate_limit":        "rate-limit",
+	"program":           "program",
+	"registry":          "registry",
+}
+
+func NewConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage config profiles",
+		// Profile may not exist yet, so don't apply it
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return silenceUsage(cmd)
+		},
+	}
+
+	configCmd.AddCommand(newConfigGetCmd())
+	configCmd.AddCommand(newConfigSetCmd())
+	configCmd.AddCommand(newConfigListCmd())
+	configCmd.AddCommand(newConfigUseCmd())
+
+	return configCmd
+}
+
+func newConfigGetCmd() *cobra.Command {
+	getCmd := &cobra.Command{
+		Use:   "get [key]",
+		Short: "Get profile value",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, _, err := ConfigFromFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			name, err := cmd.Flags().GetString("profile")
+			if err != nil {
+				return err
+			}
+
+			profile, err := cfg.Profile(name)
+			if err != nil {
+				return err
+			}
+
+			keys := config.Keys
+			if len(args) > 0 {
+				keys = args
+			}
+
+			result := map[string]string{}
+			for _, key := range keys {
+				value, err := profile.Get(key)
+				if err != nil {
+					return err
+				}
+
+				result[key] = value
+			}
+
+			return PrintResult(cmd, result, func() {
+				for _, key := range keys {
+					log.Printf("%s: %s", key, result[key])
+				}
+			})
+		},
+	}
+
+	return getCmd
+}
+
+func newConfigSetCmd() *cobra.Command {
+	setCmd := &cobra.Command{
+		Use:   "set [key] [value]",
+		Short: "Set profile value",
+		Long:  "Set profile value, profile is created if missing",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, path, err := ConfigFromFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			name, err := cmd.Flags().GetString("profile")
+			if err != nil {
+				return err
+			}
+
+			if err := cfg.ProfileOrNew(name).Set(args[0], args[1]); err != nil {
+				return err
+			}
+
+			if err := cfg.Save(path); err != nil {
+				return err
+			}
+
+			result := map[string]string{args[0]: args[1]}
+			return PrintResult(cmd, result, func() {
+				log.Printf("%s: %s", args[0], args[1])
+			})
+		},
+	}
+
+	return setCmd
+}
+
+func newConfigListCmd() *cobra.Command {
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List profiles",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, _, err := ConfigFromFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			return PrintResult(cmd, cfg, func() {
+				for _, name := range cfg.Names() {
+					marker := " "
+					if name == cfg.Current {
+						marker = "*"
+					}
+
+					log.Printf("%s %s", marker, name)
+					for _, key := range config.Keys {
+						value, _ := cfg.Profiles[name].Get(key)
+						if value != "" {
+							log.Printf("\t%s: %s", key, value)
+						}
+					}
+				}
+			})
+		},
+	}
+
+	return listCmd
+}
+
+func newConfigUseCmd() *cobra.Command {
+	useCmd := &cobra.Command{
+		Use:   "use [profile]",
+		Short: "Switch current profile",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, path, err := ConfigFromFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			if err := cfg.Use(args[0]); err != nil {
+				return err
+			}
+
+			if err := cfg.Save(path); err != nil {
+				return err
+			}
+
+			result := map[string]string{"current": cfg.Current}
+			return PrintResult(cmd, result, func() {
+				log.Printf("Current profile: %s", cfg.Current)
+			})
+		},
+	}
+
+	return useCmd
+}
+
+func ConfigFromFlag(cmd *cobra.Command) (*config.Config, string, error) {
+	path, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, "", err
+	}
+
+	if path == "" {
+		path, err = config.DefaultPath()
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	path, err = expandPath(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return cfg, path, nil
+}
+
+// Profile keys skipped if flags overriding them are set on command line
+var profileConflicts = map[string][]string{
+	"cluster": {"rpc-url", "ws-url"},
+	"rpc_url": {"cluster"},
+	"ws_url":  {"cluster", "rpc-url"},
+	"keypair": {"private", "wallet"},
+	"wallet":  {"private", "keypair"},
+}
+
+// Set flags not changed on command line from profile
+func ApplyProfile(cmd *cobra.Command) error {
+	cfg, _, err := ConfigFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	name, err := cmd.Flags().GetString("profile")
+	if err != nil {
+		return err
+	}
+
+	profile, err := cfg.Profile(name)
+	if err != nil {
+		if name == "" {
+			return nil
+		}
+		return err
+	}
+
+	if name == "" {
+		name = cfg.Current
+	}
+
+	for key, flagName := range profileFlags {
+		value, err := profile.Get(key)
+		if err != nil {
+			return err
+		}
+
+		flag := cmd.Flags().Lookup(flagName)
+		if value == "" || flag == nil || flag.Changed || conflictChanged(cmd, key) {
+			continue
+		}
+
+		if err := flag.Value.Set(value); err != nil {
+			return fmt.Errorf("profile %s key %s: %w", name, key, err)
+		}
+	}
+
+	return nil
+}
+
+func conflictChanged(cmd *cobra.Command, key string) bool {
+	for _, flagName := range profileConflicts[key] {
+		if cmd.Flags().Changed(flagName) {
+			return true
+		}
+	}
+	return false
+}
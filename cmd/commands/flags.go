@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"log"
 	"solana/pkg/client"
 
 	"github.com/gagliardetto/solana-go/rpc"
@@ -8,7 +9,19 @@ import (
 )
 
 func SetRootFlgas(rootCmd *cobra.Command) *cobra.Command {
-	rootCmd.PersistentFlags().StringP("cluster", "c", "dev", "RPC cluster. Mainnet - main, Devnet - dev")
+	rootCmd.PersistentFlags().StringP("cluster", "c", "dev", "RPC cluster. Mainnet - main, Devnet - dev, Testnet - test, Localnet - local or RPC url")
+	rootCmd.PersistentFlags().StringP("rpc-url", "", "", "RPC url, overrides cluster url")
+	rootCmd.PersistentFlags().StringP("ws-url", "", "", "Websocket url, overrides cluster url (default derived from RPC url)")
+	rootCmd.PersistentFlags().StringSliceP("backup-rpc-url", "", nil, "Backup RPC urls in order of preference, websocket urls are derived from them")
+	rootCmd.PersistentFlags().StringP("config", "", "", "Config file (default ~/.config/solana_cli/config.yaml)")
+	rootCmd.PersistentFlags().StringP("profile", "", "", "Config profile (default current profile)")
+	rootCmd.PersistentFlags().StringP("commitment", "", string(rpc.CommitmentFinalized), "Commitment of reads: processed, confirmed or finalized")
+	rootCmd.PersistentFlags().StringP("confirm-commitment", "", "", "Commitment to wait for on transaction confirmation (default read commitment)")
+	rootCmd.PersistentFlags().BoolP("poll-confirmation", "", false, "Confirm transactions by polling over HTTP instead of websocket")
+	rootCmd.PersistentFlags().BoolP("verbose", "", false, "Show health and slot lag of RPC endpoints")
+	rootCmd.PersistentFlags().Float64P("rate-limit", "", 0, "Max RPC requests per second to each endpoint, zero doesn't limit")
+	rootCmd.PersistentFlags().IntP("rate-burst", "", 1, "RPC requests over rate limit allowed at once")
+	rootCmd.PersistentFlags().IntP("max-retries", "", 3, "Retries of rate limited and failed RPC requests with backoff")
 	return rootCmd
 }
 
@@ -24,5 +37,164 @@ func ClusterFromFlag(cmd *cobra.Command) (rpc.Cluster, error) {
 		return rpc.Cluster{}, err
 	}
 
+	rpcUrl, err := flags.GetString("rpc-url")
+	if err != nil {
+		return rpc.Cluster{}, err
+	}
+
+	if rpcUrl != "" {
+		cluster.RPC = rpcUrl
+		cluster.WS, err = client.WsUrlFromRpcUrl(rpcUrl)
+		if err != nil {
+			return rpc.Cluster{}, err
+		}
+	}
+
+	wsUrl, err := flags.GetString("ws-url")
+	if err != nil {
+		return rpc.Cluster{}, err
+	}
+
+	if wsUrl != "" {
+		cluster.WS = wsUrl
+	}
+
 	return cluster, nil
 }
+
+// Get backup endpoints from flags
+func BackupsFromFlag(cmd *cobra.Command) ([]rpc.Cluster, error) {
+	urls, err := cmd.InheritedFlags().GetStringSlice("backup-rpc-url")
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]rpc.Cluster, 0, len(urls))
+	for _, url := range urls {
+		backup, err := client.ClusterFromUrl(url)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, backup)
+	}
+
+	return backups, nil
+}
+
+func ClientOptionsFromFlags(cmd *cobra.Command) (*client.ClientOptions, error) {
+	flags := cmd.InheritedFlags()
+	commitmentFlag, err := flags.GetString("commitment")
+	if err != nil {
+		return nil, err
+	}
+
+	commitment, err := client.ParseCommitment(commitmentFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmFlag, err := flags.GetString("confirm-commitment")
+	if err != nil {
+		return nil, err
+	}
+
+	confirmCommitment, err := client.ParseCommitment(confirmFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	pollConfirmation, err := flags.GetBool("poll-confirmation")
+	if err != nil {
+		return nil, err
+	}
+
+	backups, err := BackupsFromFlag(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimit, err := flags.GetFloat64("rate-limit")
+	if err != nil {
+		return nil, err
+	}
+
+	rateBurst, err := flags.GetInt("rate-burst")
+	if err != nil {
+		return nil, err
+	}
+
+	maxRetries, err := flags.GetInt("max-retries")
+	if err != nil {
+		return nil, err
+	}
+
+	return &client.ClientOptions{
+		Commitment:        commitment,
+		ConfirmCommitment: confirmCommitment,
+		PollConfirmation:  pollConfirmation,
+		Backups:           backups,
+		RateLimit:         rateLimit,
+		RateBurst:         rateBurst,
+		MaxRetries:        maxRetries,
+	}, nil
+}
+
+// Get client for cluster and commitment from flags
+func ClientFromFlags(cmd *cobra.Command) (*client.Client, error) {
+	cluster, err := ClusterFromFlag(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := ClientOptionsFromFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := client.NewClient(cmd.Context(), cluster, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	verbose, err := cmd.InheritedFlags().GetBool("verbose")
+	if err != nil {
+		return nil, err
+	}
+
+	// single endpoint has nothing to choose from
+	if len(opts.Backups) == 0 && !verbose {
+		return c, nil
+	}
+
+	for _, health := range c.CheckEndpoints(cmd.Context()) {
+		if !verbose {
+			continue
+		}
+
+		if health.Err != "" {
+			log.Printf("Endpoint %s: unhealthy, %s", health.RPC, health.Err)
+			continue
+		}
+
+		state := "healthy"
+		if !health.Healthy {
+			state = "behind"
+		}
+		log.Printf("Endpoint %s: %s, slot %d, lag %d", health.RPC, state, health.Slot, health.SlotLag)
+	}
+
+	return c, nil
+}
+
+func RegistryFromFlag(cmd *cobra.Command, cluster rpc.Cluster) (string, error) {
+	registry, err := cmd.Flags().GetString("registry")
+	if err != nil {
+		return "", err
+	}
+
+	if registry != "" {
+		return registry, nil
+	}
+
+	return client.SwapUrlFromCluster(cluster)
+}
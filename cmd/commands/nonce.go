@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+)
+
+type nonceResult struct {
+	Account              string `json:"account"`
+	Authority            string `json:"authority"`
+	Nonce                string `json:"nonce"`
+	LamportsPerSignature uint64 `json:"lamports_per_signature"`
+}
+
+type createNonceResult struct {
+	Account string `json:"account"`
+	txResult
+}
+
+func NewNonceCmd() *cobra.Command {
+	nonceCmd := &cobra.Command{
+		Use:   "nonce",
+		Short: "Manage durable nonce accounts",
+	}
+
+	nonceCmd.AddCommand(newNonceCreateCmd())
+	nonceCmd.AddCommand(newNonceShowCmd())
+	nonceCmd.AddCommand(newNonceAdvanceCmd())
+	nonceCmd.AddCommand(newNonceWithdrawCmd())
+	nonceCmd.AddCommand(newNonceAuthorizeCmd())
+
+	return nonceCmd
+}
+
+func newNonceCreateCmd() *cobra.Command {
+	var nonceKeypair string
+	var authorityKey string
+	var lamports uint64
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create nonce account",
+		Long:  "Create nonce account funded by owner, nonce account key is generated if keypair file is not set. Keypair file is required with --dump-transaction",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := SendOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			owner, err := OwnerFromFlags(cmd, opts)
+			if err != nil {
+				return err
+			}
+
+			// generated key is lost, so dumped transaction couldn't be signed by it
+			if nonceKeypair == "" && opts.DumpTransaction {
+				return errors.New("nonce keypair file is required to dump transaction")
+			}
+
+			nonceKey := solana.NewWallet().PrivateKey
+			if nonceKeypair != "" {
+				nonceKey, err = ReadKeypairFile(nonceKeypair)
+				if err != nil {
+					return err
+				}
+			}
+			opts.Signers = append(opts.Signers, nonceKey)
+
+			authority := owner
+			if authorityKey != "" {
+				authority, err = solana.PublicKeyFromBase58(authorityKey)
+				if err != nil {
+					return err
+				}
+			}
+
+			client, err := ClientFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			res, err := client.CreateNonce(cmd.Context(), owner, nonceKey.PublicKey(), authority, lamports, opts)
+			if err != nil {
+				return err
+			}
+
+			result := createNonceResult{
+				Account:  nonceKey.PublicKey().String(),
+				txResult: newTxResult(res),
+			}
+
+			return PrintTxResult(cmd, result, res, func() {
+				log.Printf("Nonce account: %s", result.Account)
+				result.print()
+			})
+		},
+	}
+
+	SetSignerFlags(createCmd)
+	SetSendFlags(createCmd)
+	createCmd.Flags().StringVarP(&nonceKeypair, "nonce-keypair", "", "", "Nonce account keypair file (default new key, required with --dump-transaction)")
+	createCmd.Flags().StringVarP(&authorityKey, "authority", "", "", "Nonce authority public key (default owner)")
+	createCmd.Flags().Uint64VarP(&lamports, "lamports", "", 0, "Lamports to fund nonce account with (default rent exemption)")
+	return createCmd
+}
+
+func newNonceShowCmd() *cobra.Command {
+	showCmd := &cobra.Command{
+		Use:   "show [nonce account]",
+		Short: "Show nonce account",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			account, err := solana.PublicKeyFromBase58(args[0])
+			if err != nil {
+				return err
+			}
+
+			client, err := ClientFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			nonce, err := client.NonceAccount(cmd.Context(), account)
+			if err != nil {
+				return err
+			}
+
+			result := nonceResult{
+				Account:              account.String(),
+				Authority:            nonce.AuthorizedPubkey.String(),
+				Nonce:                solana.Hash(nonce.Nonce).String(),
+				LamportsPerSignature: nonce.FeeCalculator.LamportsPerSignature,
+			}
+
+			return PrintResult(cmd, result, func() {
+				log.Printf("Nonce account: %s", result.Account)
+				log.Printf("Authority: %s", result.Authority)
+				log.Printf("Nonce: %s", result.Nonce)
+				log.Printf("Lamports per signature: %d", result.LamportsPerSignature)
+			})
+		},
+	}
+
+	return showCmd
+}
+
+func newNonceAdvanceCmd() *cobra.Command {
+	advanceCmd := &cobra.Command{
+		Use:   "advance [nonce account]",
+		Short: "Advance stored nonce",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			account, err := solana.PublicKeyFromBase58(args[0])
+			if err != nil {
+				return err
+			}
+
+			opts, err := SendOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			owner, err := OwnerFromFlags(cmd, opts)
+			if err != nil {
+				return err
+			}
+
+			client, err := ClientFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			res, err := client.AdvanceNonce(cmd.Context(), owner, account, opts)
+			if err != nil {
+				return err
+			}
+
+			result := newTxResult(res)
+			return PrintTxResult(cmd, result, res, result.print)
+		},
+	}
+
+	SetSignerFlags(advanceCmd)
+	SetSendFlags(advanceCmd)
+	return advanceCmd
+}
+
+func newNonceWithdrawCmd() *cobra.Command {
+	withdrawCmd := &cobra.Command{
+		Use:   "withdraw [nonce account] [recipient] [lamports]",
+		Short: "Withdraw lamports from nonce account",
+		Args:  cobra.MinimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			account, err := solana.PublicKeyFromBase58(args[0])
+			if err != nil {
+				return err
+			}
+
+			recipient, err := solana.PublicKeyFromBase58(args[1])
+			if err != nil {
+				return err
+			}
+
+			lamports, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			opts, err := SendOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			owner, err := OwnerFromFlags(cmd, opts)
+			if err != nil {
+				return err
+			}
+
+			client, err := ClientFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			res, err := client.WithdrawNonce(cmd.Context(), owner, account, recipient, lamports, opts)
+			if err != nil {
+				return err
+			}
+
+			result := newTxResult(res)
+			return PrintTxResult(cmd, result, res, result.print)
+		},
+	}
+
+	SetSignerFlags(withdrawCmd)
+	SetSendFlags(withdrawCmd)
+	return withdrawCmd
+}
+
+func newNonceAuthorizeCmd() *cobra.Command {
+	authorizeCmd := &cobra.Command{
+		Use:   "authorize [nonce account] [new authority]",
+		Short: "Set new nonce authority",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			account, err := solana.PublicKeyFromBase58(args[0])
+			if err != nil {
+				return err
+			}
+
+			authority, err := solana.PublicKeyFromBase58(args[1])
+			if err != nil {
+				return err
+			}
+
+			opts, err := SendOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			owner, err := OwnerFromFlags(cmd, opts)
+			if err != nil {
+				return err
+			}
+
+			client, err := ClientFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			res, err := client.AuthorizeNonce(cmd.Context(), owner, account, authority, opts)
+			if err != nil {
+				return err
+			}
+
+			result := newTxResult(res)
+			return PrintTxResult(cmd, result, res, result.print)
+		},
+	}
+
+	SetSignerFlags(authorizeCmd)
+	SetSendFlags(authorizeCmd)
+	return authorizeCmd
+}
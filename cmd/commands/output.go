@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"solana/pkg/client"
+	"solana/pkg/instructions"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats
+const (
+	OutputText = "text"
+	OutputJson = "json"
+	OutputYaml = "yaml"
+)
+
+// Transaction sending result
+type txResult struct {
+	Signature            string                      `json:"signature,omitempty"`
+	Accounts             []instructions.NamedAccount `json:"accounts,omitempty"`
+	PriorityFee          uint64                      `json:"priority_fee,omitempty"`
+	Simulation           *client.Simulation          `json:"simulation,omitempty"`
+	Transaction          string                      `json:"transaction,omitempty"`
+	MissingSigners       []solana.PublicKey          `json:"missing_signers,omitempty"`
+	LastValidBlockHeight uint64                      `json:"last_valid_block_height,omitempty"`
+	Status               string                      `json:"status,omitempty"`
+	Error                interface{}                 `json:"error,omitempty"`
+	Attempts             int                         `json:"attempts,omitempty"`
+}
+
+// Error already reported in command output
+type reportedError struct {
+	error
+}
+
+func newTxResult(res *client.SendResult) txResult {
+	if len(res.Accounts) > 0 {
+		return txResult{Accounts: res.Accounts}
+	}
+
+	result := txResult{
+		PriorityFee:    res.PriorityFee,
+		Simulation:     res.Simulation,
+		Transaction:    res.Transaction,
+		MissingSigners: res.MissingSigners,
+		Status:         res.Status,
+		Error:          res.Err,
+		Attempts:       res.Attempts,
+	}
+
+	if res.Transaction != "" {
+		result.LastValidBlockHeight = res.LastValidBlockHeight
+	}
+
+	// Unsigned transaction has no signature yet
+	if !res.Signature.IsZero() {
+		result.Signature = res.Signature.String()
+	}
+
+	return result
+}
+
+func (r txResult) print() {
+	if len(r.Accounts) > 0 {
+		instructions.ShowAccounts(r.Accounts)
+		return
+	}
+
+	if r.PriorityFee > 0 {
+		log.Printf("Priority fee: %d micro-lamports per CU", r.PriorityFee)
+	}
+
+	if r.Simulation != nil {
+		printSimulation(r.Simulation)
+		return
+	}
+
+	if r.Transaction != "" {
+		log.Print(r.Transaction)
+		for _, signer := range r.MissingSigners {
+			log.Printf("Missing signature: %s", signer)
+		}
+		if r.LastValidBlockHeight > 0 {
+			log.Printf("Valid until block height: %d", r.LastValidBlockHeight)
+		}
+		return
+	}
+
+	log.Print(r.Signature)
+	if r.Status != "" {
+		log.Printf("Status: %s, attempts: %d", r.Status, r.Attempts)
+	}
+	if r.Error != nil {
+		log.Printf("Error: %v", r.Error)
+	}
+}
+
+func printSimulation(sim *client.Simulation) {
+	if sim.Err != nil {
+		log.Printf("Simulation failed: %v", sim.Err)
+	} else {
+		log.Print("Simulation succeeded")
+	}
+
+	log.Printf("Compute units consumed: %d", sim.UnitsConsumed)
+	for _, line := range sim.Logs {
+		log.Print(line)
+	}
+
+	for _, balance := range sim.Balances {
+		log.Printf("%s: %d -> %d (%+d)", balance.Account, balance.Pre, balance.Post, balance.Delta())
+	}
+}
+
+func SetOutputFlags(rootCmd *cobra.Command) *cobra.Command {
+	rootCmd.PersistentFlags().StringP("output", "", OutputText, "Output format: text, json or yaml")
+	return rootCmd
+}
+
+func OutputFromFlag(cmd *cobra.Command) (string, error) {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return "", err
+	}
+
+	switch output {
+	case OutputText, OutputJson, OutputYaml:
+		return output, nil
+	default:
+		return "", fmt.Errorf("cann't parse output flag - %s", output)
+	}
+}
+
+// Print result to stdout in json or yaml, text output is done by text func
+func PrintResult(cmd *cobra.Command, result interface{}, text func()) error {
+	output, err := OutputFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	switch output {
+	case OutputJson:
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case OutputYaml:
+		return printYaml(cmd, result)
+	default:
+		text()
+		return nil
+	}
+}
+
+// Print result of transaction sending, failed and expired transactions give reported error
+func PrintTxResult(cmd *cobra.Command, result interface{}, res *client.SendResult, text func()) error {
+	if err := PrintResult(cmd, result, text); err != nil {
+		return err
+	}
+
+	switch res.Status {
+	case client.StatusFailed, client.StatusExpired:
+		return reportedError{fmt.Errorf("transaction %s", res.Status)}
+	default:
+		return nil
+	}
+}
+
+// Print command error in output format
+func PrintError(rootCmd *cobra.Command, err error) {
+	if errors.As(err, &reportedError{}) {
+		return
+	}
+
+	output, _ := rootCmd.PersistentFlags().GetString("output")
+
+	switch output {
+	case OutputJson, OutputYaml:
+		result := struct {
+			Error string `json:"error"`
+		}{err.Error()}
+
+		if output == OutputYaml {
+			printYaml(rootCmd, result)
+			return
+		}
+
+		json.NewEncoder(rootCmd.OutOrStdout()).Encode(result)
+	default:
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+}
+
+// Encode result with json tags and reformat it as yaml
+func printYaml(cmd *cobra.Command, result interface{}) error {
+	content, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return err
+	}
+	resetStyle(&node)
+
+	enc := yaml.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent(2)
+	return enc.Encode(&node)
+}
+
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"errors"
+	"log"
+	"solana/pkg/model"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+)
+
+func SetRegistryFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.PersistentFlags().StringP("registry", "", "", "Saber pools registry url (default by cluster)")
+	cmd.PersistentFlags().StringP("registry-file", "", "", "Saber pools registry file, overrides registry url")
+	cmd.PersistentFlags().DurationP("registry-ttl", "", model.DefaultRegistryTTL, "Time cached registry is used without revalidation")
+	cmd.PersistentFlags().BoolP("offline", "", false, "Load registry only from cache")
+	return cmd
+}
+
+// Load registry from file or from url with cache
+func RegistryFromFlags(cmd *cobra.Command) (*model.Registry, error) {
+	flags := cmd.Flags()
+
+	file, err := flags.GetString("registry-file")
+	if err != nil {
+		return nil, err
+	}
+
+	if file != "" {
+		return model.LoadRegistryFile(file)
+	}
+
+	cluster, err := ClusterFromFlag(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	loader, err := model.NewRegistryLoader()
+	if err != nil {
+		return nil, err
+	}
+
+	loader.TTL, err = flags.GetDuration("registry-ttl")
+	if err != nil {
+		return nil, err
+	}
+
+	loader.Offline, err = flags.GetBool("offline")
+	if err != nil {
+		return nil, err
+	}
+	loader.Cluster = cluster.RPC
+
+	url, err := RegistryFromFlag(cmd, cluster)
+	if err != nil {
+		if !loader.Offline {
+			return nil, err
+		}
+
+		// registry url of custom cluster is unknown, use registry cached for this cluster
+		registry, url, err := loader.LoadCluster(cluster.RPC)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Printf("Using cached registry %s", url)
+		return registry, nil
+	}
+
+	return loader.Load(cmd.Context(), url)
+}
+
+// Pool and token arguments of command resolved with registry loaded once
+type PoolArgs struct {
+	cmd         *cobra.Command
+	registry    *model.Registry
+	pool        *model.Pool
+	swapAccount solana.PublicKey
+}
+
+func NewPoolArgs(cmd *cobra.Command) *PoolArgs {
+	return &PoolArgs{cmd: cmd}
+}
+
+// Get registry, it's loaded on first use
+func (a *PoolArgs) Registry() (*model.Registry, error) {
+	if a.registry == nil {
+		registry, err := RegistryFromFlags(a.cmd)
+		if err != nil {
+			return nil, err
+		}
+		a.registry = registry
+	}
+
+	return a.registry, nil
+}
+
+// Get swap account from argument: public key or pool id of registry, it selects pool of tokens
+func (a *PoolArgs) SwapAccount(arg string) (solana.PublicKey, error) {
+	if account, err := solana.PublicKeyFromBase58(arg); err == nil {
+		a.swapAccount = account
+		return account, nil
+	}
+
+	registry, err := a.Registry()
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+
+	pool, err := registry.PoolByID(arg)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+
+	a.pool = pool
+	a.swapAccount = pool.Swap.Config.SwapAccount
+	return a.swapAccount, nil
+}
+
+// Get token mint from argument: public key or token symbol of selected pool
+func (a *PoolArgs) TokenMint(arg string) (solana.PublicKey, error) {
+	if mint, err := solana.PublicKeyFromBase58(arg); err == nil {
+		return mint, nil
+	}
+
+	pool, err := a.Pool()
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+
+	token, err := pool.TokenBySymbol(arg)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+
+	return token.Address, nil
+}
+
+// Get selected pool, pool of swap account is found in registry
+func (a *PoolArgs) Pool() (*model.Pool, error) {
+	if a.pool != nil {
+		return a.pool, nil
+	}
+
+	if a.swapAccount.IsZero() {
+		return nil, errors.New("pool isn't selected")
+	}
+
+	registry, err := a.Registry()
+	if err != nil {
+		return nil, err
+	}
+
+	a.pool, err = registry.PoolBySwapAccount(a.swapAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.pool, nil
+}
@@ -4,11 +4,20 @@ import "github.com/spf13/cobra"
 
 func NewRootCmd() *cobra.Command {
 	rootCmd := &cobra.Command{
-		Use:   "solana",
-		Short: "Solana cli tool",
+		Use:           "solana",
+		Short:         "Solana cli tool",
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := silenceUsage(cmd); err != nil {
+				return err
+			}
+
+			return ApplyProfile(cmd)
+		},
 	}
 
 	rootCmd = SetRootFlgas(rootCmd)
+	rootCmd = SetOutputFlags(rootCmd)
 
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
@@ -16,6 +25,20 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(NewAirdropCmd())
 	rootCmd.AddCommand(NewBalanceCmd())
 	rootCmd.AddCommand(NewWalletCmd())
+	rootCmd.AddCommand(NewConfigCmd())
+	rootCmd.AddCommand(NewTxCmd())
+	rootCmd.AddCommand(NewNonceCmd())
 
 	return rootCmd
 }
+
+// Don't print usage on errors of json and yaml output
+func silenceUsage(cmd *cobra.Command) error {
+	output, err := OutputFromFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	cmd.SilenceUsage = output != OutputText
+	return nil
+}
@@ -2,23 +2,101 @@ package cmd
 
 import (
 	"log"
-	"solana/pkg/client"
 	"solana/pkg/instructions"
 	"solana/pkg/model"
+	"solana/pkg/stableswap"
 	"strconv"
+	"time"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/spf13/cobra"
 )
 
+// Saber stable swap program account
+const saberProgramId = "SSwpkEEcbUqx4vtoEByFjSkhKdCT862DNVb52nZg1UZ"
+
+type poolStateResult struct {
+	Time                time.Time        `json:"time"`
+	Paused              bool             `json:"paused"`
+	Admin               solana.PublicKey `json:"admin"`
+	FutureAdmin         solana.PublicKey `json:"future_admin"`
+	FutureAdminDeadline time.Time        `json:"future_admin_deadline"`
+	AmpFactor           uint64           `json:"amp_factor"`
+	Ramping             bool             `json:"ramping"`
+	InitialAmpFactor    uint64           `json:"initial_amp_factor"`
+	TargetAmpFactor     uint64           `json:"target_amp_factor"`
+	RampStart           time.Time        `json:"ramp_start"`
+	RampStop            time.Time        `json:"ramp_stop"`
+	PoolTokenMint       solana.PublicKey `json:"pool_token_mint"`
+	TokenA              poolTokenResult  `json:"token_a"`
+	TokenB              poolTokenResult  `json:"token_b"`
+	Fees                poolFeesResult   `json:"fees"`
+	PoolTokenSupply     uint64           `json:"pool_token_supply"`
+	VirtualPrice        float64          `json:"virtual_price"`
+}
+
+type poolTokenResult struct {
+	Mint       solana.PublicKey `json:"mint"`
+	Reserve    solana.PublicKey `json:"reserve"`
+	FeeAccount solana.PublicKey `json:"fee_account"`
+	Balance    uint64           `json:"balance"`
+}
+
+// Fees in percents
+type poolFeesResult struct {
+	Trade         float64 `json:"trade"`
+	Withdraw      float64 `json:"withdraw"`
+	AdminTrade    float64 `json:"admin_trade"`
+	AdminWithdraw float64 `json:"admin_withdraw"`
+}
+
+type quoteResult struct {
+	AmountIn  uint64 `json:"amount_in"`
+	AmountOut uint64 `json:"amount_out"`
+	TradeFee  uint64 `json:"trade_fee"`
+	AdminFee  uint64 `json:"admin_fee"`
+}
+
+type swapResult struct {
+	ExpectedOut uint64 `json:"expected_out"`
+	MinimumOut  uint64 `json:"minimum_out"`
+	txResult
+}
+
+type withdrawResult struct {
+	ExpectedOutA uint64 `json:"expected_out_a"`
+	MinimumOutA  uint64 `json:"minimum_out_a"`
+	ExpectedOutB uint64 `json:"expected_out_b"`
+	MinimumOutB  uint64 `json:"minimum_out_b"`
+	txResult
+}
+
+type withdrawOneResult struct {
+	ExpectedOut       uint64 `json:"expected_out"`
+	MinimumOut        uint64 `json:"minimum_out"`
+	TradeFee          uint64 `json:"trade_fee"`
+	WithdrawFee       uint64 `json:"withdraw_fee"`
+	AdminFee          uint64 `json:"admin_fee"`
+	BalancedWithdrawA uint64 `json:"balanced_withdraw_a"`
+	BalancedWithdrawB uint64 `json:"balanced_withdraw_b"`
+	txResult
+}
+
 func NewSaberCmd() *cobra.Command {
 	saberCmd := &cobra.Command{
 		Use:   "saber",
 		Short: "Work with solana saber dex",
 	}
 
+	saberCmd = SetRegistryFlags(saberCmd)
+
 	saberCmd.AddCommand(newSaberSwapPoolsCmd())
+	saberCmd.AddCommand(newSaberPoolStateCmd())
+	saberCmd.AddCommand(newSaberQuoteCmd())
 	saberCmd.AddCommand(newSaberSwapCmd())
+	saberCmd.AddCommand(newSaberDepositCmd())
+	saberCmd.AddCommand(newSaberWithdrawCmd())
+	saberCmd.AddCommand(newSaberWithdrawOneCmd())
 
 	return saberCmd
 }
@@ -27,45 +105,299 @@ func newSaberSwapPoolsCmd() *cobra.Command {
 	poolsInfoCmd := &cobra.Command{
 		Use:              "pools",
 		Short:            "Swap pools info",
-		Long:             "Get swap pools info from url",
+		Long:             "Get swap pools info from registry url, cache or file",
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			registry, err := RegistryFromFlags(cmd)
+			if err != nil {
+				return err
+			}
 
-			cluster, err := ClusterFromFlag(cmd)
+			return PrintResult(cmd, registry.Summary(), registry.ListPools)
+		},
+	}
+
+	return poolsInfoCmd
+}
+
+func newSaberPoolStateCmd() *cobra.Command {
+	var at int64
+
+	poolStateCmd := &cobra.Command{
+		Use:   "pool-state [swap account|pool id]",
+		Short: "Swap pool state",
+		Long:  "Get on-chain swap pool state: admin, amp factor ramp, tokens, fees, live reserves, pool token supply and virtual price",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			poolArgs := NewPoolArgs(cmd)
+			swapAccount, err := poolArgs.SwapAccount(args[0])
 			if err != nil {
 				return err
 			}
 
-			url, err := client.SwapUrlFromCluster(cluster)
+			client, err := ClientFromFlags(cmd)
 			if err != nil {
 				return err
 			}
+			defer client.Close()
 
-			swapInfo, err := model.NewJsonSwapInfo(url)
+			swapInfo, err := client.SwapInfo(cmd.Context(), swapAccount)
 			if err != nil {
 				return err
 			}
 
-			swapInfo.ListPools()
-			return nil
+			balances, err := client.PoolBalances(cmd.Context(), swapInfo)
+			if err != nil {
+				return err
+			}
+
+			ts := at
+			if ts == 0 {
+				ts, err = client.BlockTime(cmd.Context())
+				if err != nil {
+					return err
+				}
+			}
+
+			fees := swapInfo.Fees
+			if fees == nil {
+				fees = &model.Fees{}
+			}
+
+			result := poolStateResult{
+				Time:                time.Unix(ts, 0).UTC(),
+				Paused:              swapInfo.IsPaused,
+				Admin:               swapInfo.AdminKey,
+				FutureAdmin:         swapInfo.FutureAdminKey,
+				FutureAdminDeadline: time.Unix(swapInfo.FutureAdminDeadline, 0).UTC(),
+				AmpFactor:           swapInfo.AmpFactor(ts),
+				Ramping:             swapInfo.IsRamping(ts),
+				InitialAmpFactor:    swapInfo.InitialAmpFactor,
+				TargetAmpFactor:     swapInfo.TargetAmpFactor,
+				RampStart:           time.Unix(swapInfo.StartRampTs, 0).UTC(),
+				RampStop:            time.Unix(swapInfo.StopRampTs, 0).UTC(),
+				PoolTokenMint:       swapInfo.PoolTokenMint,
+				TokenA: poolTokenResult{
+					Mint:       swapInfo.TokenAMint,
+					Reserve:    swapInfo.TokenAReserve,
+					FeeAccount: swapInfo.TokenAFee,
+					Balance:    balances.ReserveA,
+				},
+				TokenB: poolTokenResult{
+					Mint:       swapInfo.TokenBMint,
+					Reserve:    swapInfo.TokenBReserve,
+					FeeAccount: swapInfo.TokenBFee,
+					Balance:    balances.ReserveB,
+				},
+				Fees: poolFeesResult{
+					Trade:         percent(fees.TradeFeeNumerator, fees.TradeFeeDenominator),
+					Withdraw:      percent(fees.WithdrawFeeNumerator, fees.WithdrawFeeDenominator),
+					AdminTrade:    percent(fees.AdminTradeFeeNumerator, fees.AdminTradeFeeDenominator),
+					AdminWithdraw: percent(fees.AdminWithdrawFeeNumerator, fees.AdminWithdrawDeeDenominator),
+				},
+				PoolTokenSupply: balances.PoolTokenSupply,
+				VirtualPrice:    stableswap.New(swapInfo, ts).VirtualPrice(balances.ReserveA, balances.ReserveB, balances.PoolTokenSupply),
+			}
+
+			return PrintResult(cmd, result, func() {
+				log.Printf("Time: %s", result.Time)
+				log.Printf("Paused: %t", result.Paused)
+				log.Printf("Admin: %s", result.Admin)
+				log.Printf("Future admin: %s", result.FutureAdmin)
+				log.Printf("Future admin deadline: %s", result.FutureAdminDeadline)
+				log.Printf("Amp factor: %d", result.AmpFactor)
+				log.Printf("Ramp running: %t", result.Ramping)
+				log.Printf("Initial amp factor: %d", result.InitialAmpFactor)
+				log.Printf("Target amp factor: %d", result.TargetAmpFactor)
+				log.Printf("Ramp start: %s", result.RampStart)
+				log.Printf("Ramp stop: %s", result.RampStop)
+				log.Printf("Pool token mint: %s", result.PoolTokenMint)
+				log.Printf("Pool token supply: %d", result.PoolTokenSupply)
+				log.Printf("Virtual price: %.6f", result.VirtualPrice)
+				result.TokenA.print("A")
+				result.TokenB.print("B")
+				log.Printf("Trade fee: %.4f%%", result.Fees.Trade)
+				log.Printf("Withdraw fee: %.4f%%", result.Fees.Withdraw)
+				log.Printf("Admin trade fee: %.4f%%", result.Fees.AdminTrade)
+				log.Printf("Admin withdraw fee: %.4f%%", result.Fees.AdminWithdraw)
+			})
 		},
 	}
 
-	return poolsInfoCmd
+	poolStateCmd.Flags().Int64VarP(&at, "at", "", 0, "Unix time to compute amp factor at (default cluster block time)")
+	return poolStateCmd
+}
+
+func (t poolTokenResult) print(name string) {
+	log.Printf("Token %s mint: %s", name, t.Mint)
+	log.Printf("Token %s reserve: %s, balance: %d", name, t.Reserve, t.Balance)
+	log.Printf("Token %s fee account: %s", name, t.FeeAccount)
+}
+
+// Get fraction in percents, zero denominator gives zero
+func percent(numerator, denominator uint64) float64 {
+	if denominator == 0 {
+		return 0
+	}
+
+	return float64(numerator) / float64(denominator) * 100
+}
+
+func newSaberQuoteCmd() *cobra.Command {
+	quoteCmd := &cobra.Command{
+		Use:   "quote [swap account|pool id] [amount] [token mint|symbol a] [token mint|symbol b]",
+		Short: "Quote swap output",
+		Long:  "Compute expected swap output from on-chain pool state",
+		Args:  cobra.MinimumNArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			poolArgs := NewPoolArgs(cmd)
+			swapAccount, err := poolArgs.SwapAccount(args[0])
+			if err != nil {
+				return err
+			}
+
+			amount, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			tokenA, err := poolArgs.TokenMint(args[2])
+			if err != nil {
+				return err
+			}
+
+			tokenB, err := poolArgs.TokenMint(args[3])
+			if err != nil {
+				return err
+			}
+
+			client, err := ClientFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			quote, err := client.Quote(cmd.Context(), swapAccount, tokenA, tokenB, amount)
+			if err != nil {
+				return err
+			}
+
+			result := quoteResult{
+				AmountIn:  quote.AmountIn,
+				AmountOut: quote.AmountOut,
+				TradeFee:  quote.TradeFee,
+				AdminFee:  quote.AdminFee,
+			}
+
+			return PrintResult(cmd, result, func() {
+				log.Printf("Amount in: %d", result.AmountIn)
+				log.Printf("Amount out: %d", result.AmountOut)
+				log.Printf("Trade fee: %d", result.TradeFee)
+				log.Printf("Admin fee: %d", result.AdminFee)
+			})
+		},
+	}
+
+	return quoteCmd
 }
 
 func newSaberSwapCmd() *cobra.Command {
 
 	var programIdKey string
-	var privateKey string
-	var showAccounts bool
+	var slippageBps uint64
 
 	saberSwapCmd := &cobra.Command{
-		Use:   "swap [swap account] [amount] [token mint a] [amount] [token mint b]",
+		Use:   "swap [swap account|pool id] [amount] [token mint|symbol a] [token mint|symbol b]",
 		Short: "Swap tokens",
-		Args:  cobra.MinimumNArgs(5),
+		Args:  cobra.MinimumNArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cluster, err := ClusterFromFlag(cmd)
+			amountTokenA, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			poolArgs := NewPoolArgs(cmd)
+			swapAccount, err := poolArgs.SwapAccount(args[0])
+			if err != nil {
+				return err
+			}
+
+			tokenA, err := poolArgs.TokenMint(args[2])
+			if err != nil {
+				return err
+			}
+
+			tokenB, err := poolArgs.TokenMint(args[3])
+			if err != nil {
+				return err
+			}
+
+			programId, err := solana.PublicKeyFromBase58(programIdKey)
+			if err != nil {
+				return err
+			}
+
+			opts, err := SendOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			owner, err := OwnerFromFlags(cmd, opts)
+			if err != nil {
+				return err
+			}
+
+			client, err := ClientFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			quote, err := client.Quote(cmd.Context(), swapAccount, tokenA, tokenB, amountTokenA)
+			if err != nil {
+				return err
+			}
+
+			minimumOut := stableswap.MinimumAmountOut(quote.AmountOut, slippageBps)
+
+			swapData := instructions.NewSwapData(amountTokenA, minimumOut)
+
+			res, err := client.Swap(cmd.Context(), programId, swapAccount, tokenA, tokenB, owner, swapData, opts)
+			if err != nil {
+				return err
+			}
+
+			result := swapResult{
+				ExpectedOut: quote.AmountOut,
+				MinimumOut:  minimumOut,
+				txResult:    newTxResult(res),
+			}
+
+			return PrintTxResult(cmd, result, res, func() {
+				log.Printf("Expected out: %d, minimum out: %d", result.ExpectedOut, result.MinimumOut)
+				result.print()
+			})
+		},
+	}
+
+	SetSignerFlags(saberSwapCmd)
+	saberSwapCmd.Flags().StringVarP(&programIdKey, "program", "", saberProgramId, "Stabe Swap Program Account")
+	SetSendFlags(saberSwapCmd)
+	saberSwapCmd.Flags().Uint64VarP(&slippageBps, "slippage-bps", "", 50, "Max slippage from quoted output in basis points")
+	return saberSwapCmd
+}
+
+func newSaberDepositCmd() *cobra.Command {
+
+	var programIdKey string
+
+	saberDepositCmd := &cobra.Command{
+		Use:   "deposit [swap account|pool id] [amount A] [amount B] [min LP]",
+		Short: "Deposit tokens into pool",
+		Args:  cobra.MinimumNArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			poolArgs := NewPoolArgs(cmd)
+			swapAccount, err := poolArgs.SwapAccount(args[0])
 			if err != nil {
 				return err
 			}
@@ -75,24 +407,157 @@ func newSaberSwapCmd() *cobra.Command {
 				return err
 			}
 
-			amountTokenB, err := strconv.ParseUint(args[3], 10, 64)
+			amountTokenB, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			minimumMint, err := strconv.ParseUint(args[3], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			depositData := instructions.NewDepositData(amountTokenA, amountTokenB, minimumMint)
+
+			programId, err := solana.PublicKeyFromBase58(programIdKey)
+			if err != nil {
+				return err
+			}
+
+			opts, err := SendOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			owner, err := OwnerFromFlags(cmd, opts)
+			if err != nil {
+				return err
+			}
+
+			client, err := ClientFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			res, err := client.Deposit(cmd.Context(), programId, swapAccount, owner, depositData, opts)
+			if err != nil {
+				return err
+			}
+
+			result := newTxResult(res)
+			return PrintTxResult(cmd, result, res, result.print)
+		},
+	}
+
+	SetSignerFlags(saberDepositCmd)
+	saberDepositCmd.Flags().StringVarP(&programIdKey, "program", "", saberProgramId, "Stabe Swap Program Account")
+	SetSendFlags(saberDepositCmd)
+	return saberDepositCmd
+}
+
+func newSaberWithdrawCmd() *cobra.Command {
+
+	var programIdKey string
+	var slippageBps uint64
+
+	saberWithdrawCmd := &cobra.Command{
+		Use:   "withdraw [swap account|pool id] [lp amount]",
+		Short: "Withdraw tokens from pool",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			poolArgs := NewPoolArgs(cmd)
+			swapAccount, err := poolArgs.SwapAccount(args[0])
+			if err != nil {
+				return err
+			}
+
+			poolTokenAmount, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			programId, err := solana.PublicKeyFromBase58(programIdKey)
+			if err != nil {
+				return err
+			}
+
+			opts, err := SendOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			owner, err := OwnerFromFlags(cmd, opts)
+			if err != nil {
+				return err
+			}
+
+			client, err := ClientFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			quote, err := client.WithdrawQuote(cmd.Context(), swapAccount, poolTokenAmount)
+			if err != nil {
+				return err
+			}
+
+			minimumA := stableswap.MinimumAmountOut(quote.AmountA, slippageBps)
+			minimumB := stableswap.MinimumAmountOut(quote.AmountB, slippageBps)
+
+			withdrawData := instructions.NewWithdrawData(poolTokenAmount, minimumA, minimumB)
+
+			res, err := client.Withdraw(cmd.Context(), programId, swapAccount, owner, withdrawData, opts)
 			if err != nil {
 				return err
 			}
 
-			swapData := instructions.NewSwapData(amountTokenA, amountTokenB)
+			result := withdrawResult{
+				ExpectedOutA: quote.AmountA,
+				MinimumOutA:  minimumA,
+				ExpectedOutB: quote.AmountB,
+				MinimumOutB:  minimumB,
+				txResult:     newTxResult(res),
+			}
+
+			return PrintTxResult(cmd, result, res, func() {
+				log.Printf("Expected out A: %d, minimum out A: %d", result.ExpectedOutA, result.MinimumOutA)
+				log.Printf("Expected out B: %d, minimum out B: %d", result.ExpectedOutB, result.MinimumOutB)
+				result.print()
+			})
+		},
+	}
 
-			swapAccount, err := solana.PublicKeyFromBase58(args[0])
+	SetSignerFlags(saberWithdrawCmd)
+	saberWithdrawCmd.Flags().StringVarP(&programIdKey, "program", "", saberProgramId, "Stabe Swap Program Account")
+	SetSendFlags(saberWithdrawCmd)
+	saberWithdrawCmd.Flags().Uint64VarP(&slippageBps, "slippage-bps", "", 50, "Max slippage from quoted output in basis points")
+	return saberWithdrawCmd
+}
+
+func newSaberWithdrawOneCmd() *cobra.Command {
+
+	var programIdKey string
+	var slippageBps uint64
+
+	saberWithdrawOneCmd := &cobra.Command{
+		Use:   "withdraw-one [swap account|pool id] [lp amount] [output mint|symbol]",
+		Short: "Withdraw one token from pool",
+		Args:  cobra.MinimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			poolArgs := NewPoolArgs(cmd)
+			swapAccount, err := poolArgs.SwapAccount(args[0])
 			if err != nil {
 				return err
 			}
 
-			tokenA, err := solana.PublicKeyFromBase58(args[2])
+			poolTokenAmount, err := strconv.ParseUint(args[1], 10, 64)
 			if err != nil {
 				return err
 			}
 
-			tokenB, err := solana.PublicKeyFromBase58(args[4])
+			token, err := poolArgs.TokenMint(args[2])
 			if err != nil {
 				return err
 			}
@@ -102,30 +567,64 @@ func newSaberSwapCmd() *cobra.Command {
 				return err
 			}
 
-			wallet, err := solana.WalletFromPrivateKeyBase58(privateKey)
+			opts, err := SendOptionsFromFlags(cmd)
 			if err != nil {
 				return err
 			}
 
-			client, err := client.NewClient(cmd.Context(), cluster)
+			owner, err := OwnerFromFlags(cmd, opts)
+			if err != nil {
+				return err
+			}
+
+			client, err := ClientFromFlags(cmd)
 			if err != nil {
 				return err
 			}
 			defer client.Close()
 
-			sig, err := client.Swap(cmd.Context(), programId, swapAccount, tokenA, tokenB, wallet, swapData, showAccounts)
+			quote, err := client.WithdrawOneQuote(cmd.Context(), swapAccount, token, poolTokenAmount)
+			if err != nil {
+				return err
+			}
+
+			balanced, err := client.WithdrawQuote(cmd.Context(), swapAccount, poolTokenAmount)
+			if err != nil {
+				return err
+			}
+
+			minimumOut := stableswap.MinimumAmountOut(quote.AmountOut, slippageBps)
+
+			withdrawData := instructions.NewWithdrawOneData(poolTokenAmount, minimumOut)
+
+			res, err := client.WithdrawOne(cmd.Context(), programId, swapAccount, token, owner, withdrawData, opts)
 			if err != nil {
 				return err
 			}
 
-			log.Print(sig.String())
+			result := withdrawOneResult{
+				ExpectedOut:       quote.AmountOut,
+				MinimumOut:        minimumOut,
+				TradeFee:          quote.TradeFee,
+				WithdrawFee:       quote.WithdrawFee,
+				AdminFee:          quote.AdminFee,
+				BalancedWithdrawA: balanced.AmountA,
+				BalancedWithdrawB: balanced.AmountB,
+				txResult:          newTxResult(res),
+			}
 
-			return nil
+			return PrintTxResult(cmd, result, res, func() {
+				log.Printf("Expected out: %d, minimum out: %d", result.ExpectedOut, result.MinimumOut)
+				log.Printf("Trade fee: %d, withdraw fee: %d, admin fee: %d", result.TradeFee, result.WithdrawFee, result.AdminFee)
+				log.Printf("Balanced withdraw: A %d, B %d", result.BalancedWithdrawA, result.BalancedWithdrawB)
+				result.print()
+			})
 		},
 	}
 
-	saberSwapCmd.PersistentFlags().StringVarP(&privateKey, "private", "p", "", "Private key")
-	saberSwapCmd.Flags().StringVarP(&programIdKey, "program", "", "SSwpkEEcbUqx4vtoEByFjSkhKdCT862DNVb52nZg1UZ", "Stabe Swap Program Account")
-	saberSwapCmd.Flags().BoolVarP(&showAccounts, "show", "s", false, "Show accounts in instruction (Don't send transaction)")
-	return saberSwapCmd
+	SetSignerFlags(saberWithdrawOneCmd)
+	saberWithdrawOneCmd.Flags().StringVarP(&programIdKey, "program", "", saberProgramId, "Stabe Swap Program Account")
+	SetSendFlags(saberWithdrawOneCmd)
+	saberWithdrawOneCmd.Flags().Uint64VarP(&slippageBps, "slippage-bps", "", 50, "Max slippage from quoted output in basis points")
+	return saberWithdrawOneCmd
 }
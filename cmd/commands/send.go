@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"solana/pkg/client"
+	"strconv"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+)
+
+// Priority fee flag value to take fee from recent fees
+const autoPriorityFee = "auto"
+
+func SetSendFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().BoolP("show", "s", false, "Show accounts in instruction (Don't send transaction)")
+	cmd.Flags().BoolP("simulate", "", false, "Simulate transaction and show logs and balance changes (Don't send transaction)")
+	cmd.Flags().Uint32P("compute-unit-limit", "", 0, "Compute unit limit of transaction (default runtime limit)")
+	cmd.Flags().StringP("priority-fee", "", "", "Priority fee in micro-lamports per compute unit or auto to use recent fees")
+	cmd.Flags().IntP("priority-fee-percentile", "", client.DefaultFeePercentile, "Percentile of recent fees for auto priority fee")
+	cmd.Flags().StringP("owner", "", "", "Owner public key when signing key is offline (default signer key)")
+	cmd.Flags().BoolP("sign-only", "", false, "Sign with available keys and print transaction (Don't send transaction)")
+	cmd.Flags().BoolP("dump-transaction", "", false, "Print unsigned transaction (Don't send transaction)")
+	cmd.Flags().StringP("encoding", "", client.EncodingBase64, "Printed transaction encoding: base64 or base58")
+	cmd.Flags().StringP("blockhash", "", "", "Blockhash to use instead of recent one")
+	cmd.Flags().StringP("nonce", "", "", "Durable nonce account to use instead of recent blockhash")
+	cmd.Flags().StringP("nonce-authority", "", "", "Nonce authority public key (default owner)")
+	SetRebroadcastFlags(cmd)
+	cmd.Flags().IntP("resign", "", 0, "Max re-signs of expired transaction with new blockhash")
+	return cmd
+}
+
+func SendOptionsFromFlags(cmd *cobra.Command) (*client.SendOptions, error) {
+	flags := cmd.Flags()
+
+	showAccounts, err := flags.GetBool("show")
+	if err != nil {
+		return nil, err
+	}
+
+	simulate, err := flags.GetBool("simulate")
+	if err != nil {
+		return nil, err
+	}
+
+	computeUnitLimit, err := flags.GetUint32("compute-unit-limit")
+	if err != nil {
+		return nil, err
+	}
+
+	priorityFeeFlag, err := flags.GetString("priority-fee")
+	if err != nil {
+		return nil, err
+	}
+
+	percentile, err := flags.GetInt("priority-fee-percentile")
+	if err != nil {
+		return nil, err
+	}
+
+	if percentile < 0 || percentile > 100 {
+		return nil, fmt.Errorf("priority fee percentile must be in 0..100 - %d", percentile)
+	}
+
+	signOnly, err := flags.GetBool("sign-only")
+	if err != nil {
+		return nil, err
+	}
+
+	dumpTransaction, err := flags.GetBool("dump-transaction")
+	if err != nil {
+		return nil, err
+	}
+
+	encoding, err := EncodingFromFlag(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	resigns, err := flags.GetInt("resign")
+	if err != nil {
+		return nil, err
+	}
+
+	interval, maxAttempts, err := RebroadcastFromFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &client.SendOptions{
+		RebroadcastInterval:   interval,
+		MaxAttempts:           maxAttempts,
+		Resigns:               resigns,
+		ShowAccounts:          showAccounts,
+		Simulate:              simulate,
+		ComputeUnitLimit:      computeUnitLimit,
+		PriorityFeePercentile: percentile,
+		SignOnly:              signOnly,
+		DumpTransaction:       dumpTransaction,
+		Encoding:              encoding,
+	}
+
+	blockhash, err := flags.GetString("blockhash")
+	if err != nil {
+		return nil, err
+	}
+
+	if blockhash != "" {
+		hash, err := solana.HashFromBase58(blockhash)
+		if err != nil {
+			return nil, fmt.Errorf("cann't parse blockhash flag - %s", blockhash)
+		}
+		opts.Blockhash = &hash
+	}
+
+	nonce, err := flags.GetString("nonce")
+	if err != nil {
+		return nil, err
+	}
+
+	if nonce != "" {
+		if blockhash != "" {
+			return nil, errors.New("blockhash and nonce flags can't be used together")
+		}
+
+		nonceAccount, err := solana.PublicKeyFromBase58(nonce)
+		if err != nil {
+			return nil, fmt.Errorf("cann't parse nonce flag - %s", nonce)
+		}
+		opts.Nonce = &nonceAccount
+	}
+
+	nonceAuthority, err := flags.GetString("nonce-authority")
+	if err != nil {
+		return nil, err
+	}
+
+	if nonceAuthority != "" {
+		authority, err := solana.PublicKeyFromBase58(nonceAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("cann't parse nonce authority flag - %s", nonceAuthority)
+		}
+		opts.NonceAuthority = &authority
+	}
+
+	switch priorityFeeFlag {
+	case "":
+	case autoPriorityFee:
+		opts.AutoPriorityFee = true
+	default:
+		opts.PriorityFee, err = strconv.ParseUint(priorityFeeFlag, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cann't parse priority fee flag - %s", priorityFeeFlag)
+		}
+	}
+
+	return opts, nil
+}
+
+func SetRebroadcastFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().DurationP("rebroadcast-interval", "", client.DefaultRebroadcastInterval, "Interval of transaction rebroadcast until confirmation")
+	cmd.Flags().IntP("max-attempts", "", 0, "Max sends of transaction (default until blockhash expiration)")
+	return cmd
+}
+
+// Get rebroadcast interval and max attempts
+func RebroadcastFromFlags(cmd *cobra.Command) (time.Duration, int, error) {
+	interval, err := cmd.Flags().GetDuration("rebroadcast-interval")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if interval <= 0 {
+		return 0, 0, fmt.Errorf("rebroadcast interval must be positive - %s", interval)
+	}
+
+	maxAttempts, err := cmd.Flags().GetInt("max-attempts")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return interval, maxAttempts, nil
+}
+
+// Get transaction owner and add signer key to send options.
+// Key is not needed to dump transaction of owner set by flag.
+func OwnerFromFlags(cmd *cobra.Command, opts *client.SendOptions) (solana.PublicKey, error) {
+	ownerKey, err := cmd.Flags().GetString("owner")
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+
+	if ownerKey != "" && opts.DumpTransaction {
+		return solana.PublicKeyFromBase58(ownerKey)
+	}
+
+	wallet, err := WalletFromFlags(cmd)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+
+	opts.Signers = append(opts.Signers, wallet.PrivateKey)
+
+	if ownerKey != "" {
+		return solana.PublicKeyFromBase58(ownerKey)
+	}
+
+	return wallet.PublicKey(), nil
+}
+
+func EncodingFromFlag(cmd *cobra.Command) (string, error) {
+	encoding, err := cmd.Flags().GetString("encoding")
+	if err != nil {
+		return "", err
+	}
+
+	switch encoding {
+	case client.EncodingBase64, client.EncodingBase58:
+		return encoding, nil
+	default:
+		return "", fmt.Errorf("cann't parse encoding flag - %s", encoding)
+	}
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Default solana-keygen keypair file
+const defaultKeypairPath = "~/.config/solana/id.json"
+
+// Environment variables with secrets for non-interactive use
+const (
+	passphraseEnv = "SOLANA_CLI_PASSPHRASE"
+	mnemonicEnv   = "SOLANA_CLI_MNEMONIC"
+)
+
+// Shared stdin reader, so several secrets can be piped in
+var stdin = bufio.NewReader(os.Stdin)
+
+func SetSignerFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().StringP("private", "p", "", "Private key in base58 (prefer --keypair)")
+	cmd.Flags().StringP("keypair", "k", defaultKeypairPath, "Keypair file in solana-keygen format")
+	cmd.Flags().StringP("wallet", "w", "", "Keystore wallet name")
+	return cmd
+}
+
+func WalletFromFlags(cmd *cobra.Command) (*solana.Wallet, error) {
+	flags := cmd.Flags()
+
+	privateKey, err := flags.GetString("private")
+	if err != nil {
+		return nil, err
+	}
+
+	if privateKey != "" {
+		return solana.WalletFromPrivateKeyBase58(privateKey)
+	}
+
+	walletName, err := flags.GetString("wallet")
+	if err != nil {
+		return nil, err
+	}
+
+	if walletName != "" {
+		key, err := decryptWallet(walletName)
+		if err != nil {
+			return nil, err
+		}
+
+		return &solana.Wallet{PrivateKey: key}, nil
+	}
+
+	keypair, err := flags.GetString("keypair")
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ReadKeypairFile(keypair)
+	if err != nil {
+		return nil, err
+	}
+
+	return &solana.Wallet{PrivateKey: key}, nil
+}
+
+func ReadKeypairFile(path string) (solana.PrivateKey, error) {
+	path, err := expandPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := solana.PrivateKeyFromSolanaKeygenFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key) != 64 {
+		return nil, fmt.Errorf("keypair file %s must contain 64 bytes, got %d", path, len(key))
+	}
+
+	return key, nil
+}
+
+func WriteKeypairFile(path string, key solana.PrivateKey, force bool) error {
+	if err := checkKeypairFile(path, force); err != nil {
+		return err
+	}
+
+	path, err := expandPath(path)
+	if err != nil {
+		return err
+	}
+
+	values := make([]int, len(key))
+	for i, b := range key {
+		values[i] = int(b)
+	}
+
+	content, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0600)
+}
+
+// Check keypair file doesn't exist or may be overwritten
+func checkKeypairFile(path string, force bool) error {
+	path, err := expandPath(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("keypair file %s already exists", path)
+	}
+
+	return nil
+}
+
+func ReadPassphrase(prompt string, confirm bool) ([]byte, error) {
+	return readSecret(prompt, passphraseEnv, confirm)
+}
+
+func ReadMnemonic() (string, error) {
+	mnemonic, err := readSecret("Mnemonic: ", mnemonicEnv, false)
+	if err != nil {
+		return "", err
+	}
+
+	return string(mnemonic), nil
+}
+
+func readSecret(prompt, env string, confirm bool) ([]byte, error) {
+	if secret, ok := os.LookupEnv(env); ok {
+		return []byte(secret), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		line, err := stdin.ReadString('\n')
+		if err != nil && line == "" {
+			return nil, err
+		}
+		return []byte(strings.TrimRight(line, "\r\n")), nil
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	secret, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat: ")
+		repeat, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, err
+		}
+
+		if string(repeat) != string(secret) {
+			return nil, errors.New("inputs do not match")
+		}
+	}
+
+	return secret, nil
+}
+
+func expandPath(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
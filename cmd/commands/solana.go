@@ -1,25 +1,30 @@
 package cmd
 
 import (
+	"fmt"
 	"log"
-	"solana/pkg/client"
 	"strconv"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/spf13/cobra"
 )
 
+type airdropResult struct {
+	Signature string `json:"signature"`
+}
+
+type balanceResult struct {
+	PublicKey string `json:"public_key"`
+	Lamports  uint64 `json:"lamports"`
+	Sol       string `json:"sol"`
+}
+
 func NewAirdropCmd() *cobra.Command {
 	airdropCmd := &cobra.Command{
 		Use:   "airdrop [public key] [amount]",
 		Short: "Request airdrop to account",
 		Args:  cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cluster, err := ClusterFromFlag(cmd)
-			if err != nil {
-				return err
-			}
-
 			publicKey, err := solana.PublicKeyFromBase58(args[0])
 			if err != nil {
 				return err
@@ -30,7 +35,7 @@ func NewAirdropCmd() *cobra.Command {
 				return err
 			}
 
-			client, err := client.NewClient(cmd.Context(), cluster)
+			client, err := ClientFromFlags(cmd)
 			if err != nil {
 				return err
 			}
@@ -41,8 +46,10 @@ func NewAirdropCmd() *cobra.Command {
 				return err
 			}
 
-			log.Println(sig.String())
-			return nil
+			result := airdropResult{Signature: sig.String()}
+			return PrintResult(cmd, result, func() {
+				log.Println(result.Signature)
+			})
 		},
 	}
 
@@ -55,17 +62,12 @@ func NewBalanceCmd() *cobra.Command {
 		Short: "Get balance",
 		Args:  cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cluster, err := ClusterFromFlag(cmd)
-			if err != nil {
-				return err
-			}
-
 			publicKey, err := solana.PublicKeyFromBase58(args[0])
 			if err != nil {
 				return err
 			}
 
-			client, err := client.NewClient(cmd.Context(), cluster)
+			client, err := ClientFromFlags(cmd)
 			if err != nil {
 				return err
 			}
@@ -76,26 +78,18 @@ func NewBalanceCmd() *cobra.Command {
 				return err
 			}
 
-			log.Printf("Balance lamports: %d", out)
-			log.Printf("Balance sol: %d,%d SOL", out/solana.LAMPORTS_PER_SOL, out%solana.LAMPORTS_PER_SOL)
-			return nil
-		},
-	}
-
-	return balanceCmd
-}
-
-func NewWalletCmd() *cobra.Command {
-	walletCmd := &cobra.Command{
-		Use:   "wallet",
-		Short: "Create new wallet",
-		Run: func(cmd *cobra.Command, args []string) {
-			wallet := solana.NewWallet()
+			result := balanceResult{
+				PublicKey: publicKey.String(),
+				Lamports:  out,
+				Sol:       fmt.Sprintf("%d.%09d", out/solana.LAMPORTS_PER_SOL, out%solana.LAMPORTS_PER_SOL),
+			}
 
-			log.Println("Private key: ", wallet.PrivateKey.String())
-			log.Println("Public key: ", wallet.PublicKey().String())
+			return PrintResult(cmd, result, func() {
+				log.Printf("Balance lamports: %d", out)
+				log.Printf("Balance sol: %d,%d SOL", out/solana.LAMPORTS_PER_SOL, out%solana.LAMPORTS_PER_SOL)
+			})
 		},
 	}
 
-	return walletCmd
+	return balanceCmd
 }
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"io"
+	"log"
+	"solana/pkg/client"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+)
+
+func NewTxCmd() *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Sign and send serialized transactions",
+	}
+
+	txCmd.AddCommand(newTxSignCmd())
+	txCmd.AddCommand(newTxSendCmd())
+
+	return txCmd
+}
+
+func newTxSignCmd() *cobra.Command {
+	signCmd := &cobra.Command{
+		Use:   "sign [transaction]",
+		Short: "Sign transaction",
+		Long:  "Add signature of local key to serialized transaction, transaction is read from stdin if missing or -",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			encoding, err := EncodingFromFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			tx, err := transactionFromArgs(args, encoding)
+			if err != nil {
+				return err
+			}
+
+			wallet, err := WalletFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			if !tx.IsSigner(wallet.PublicKey()) {
+				log.Printf("Key %s is not a signer of transaction", wallet.PublicKey())
+			}
+
+			if err := client.PartialSign(tx, []solana.PrivateKey{wallet.PrivateKey}); err != nil {
+				return err
+			}
+
+			content, err := client.EncodeTransaction(tx, encoding)
+			if err != nil {
+				return err
+			}
+
+			result := newTxResult(&client.SendResult{
+				Signature:      tx.Signatures[0],
+				Transaction:    content,
+				MissingSigners: client.MissingSigners(tx),
+			})
+
+			return PrintResult(cmd, result, result.print)
+		},
+	}
+
+	SetSignerFlags(signCmd)
+	signCmd.Flags().StringP("encoding", "", client.EncodingBase64, "Transaction encoding: base64 or base58")
+	return signCmd
+}
+
+func newTxSendCmd() *cobra.Command {
+	var simulate bool
+
+	sendCmd := &cobra.Command{
+		Use:   "send [transaction]",
+		Short: "Send signed transaction",
+		Long:  "Send signed serialized transaction and wait for confirmation, transaction is read from stdin if missing or -",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			encoding, err := EncodingFromFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			tx, err := transactionFromArgs(args, encoding)
+			if err != nil {
+				return err
+			}
+
+			interval, maxAttempts, err := RebroadcastFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			opts := &client.SendOptions{
+				Simulate:            simulate,
+				RebroadcastInterval: interval,
+				MaxAttempts:         maxAttempts,
+			}
+
+			client, err := ClientFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			// expiration is checked by blockhash of transaction
+			res, err := client.SendTransaction(cmd.Context(), tx, 0, opts)
+			if err != nil {
+				return err
+			}
+
+			result := newTxResult(res)
+			return PrintTxResult(cmd, result, res, result.print)
+		},
+	}
+
+	SetRebroadcastFlags(sendCmd)
+	sendCmd.Flags().StringP("encoding", "", client.EncodingBase64, "Transaction encoding: base64 or base58")
+	sendCmd.Flags().BoolVarP(&simulate, "simulate", "", false, "Simulate transaction and show logs (Don't send transaction)")
+	return sendCmd
+}
+
+func transactionFromArgs(args []string, encoding string) (*solana.Transaction, error) {
+	var content string
+	if len(args) > 0 && args[0] != "-" {
+		content = args[0]
+	} else {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, err
+		}
+		content = string(data)
+	}
+
+	return client.DecodeTransaction(strings.TrimSpace(content), encoding)
+}
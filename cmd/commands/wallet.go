@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"errors"
+	"log"
+	"solana/pkg/hdwallet"
+	"solana/pkg/keystore"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+)
+
+type walletResult struct {
+	Name           string `json:"name,omitempty"`
+	PublicKey      string `json:"public_key,omitempty"`
+	PrivateKey     string `json:"private_key,omitempty"`
+	KeypairFile    string `json:"keypair_file,omitempty"`
+	Mnemonic       string `json:"mnemonic,omitempty"`
+	DerivationPath string `json:"derivation_path,omitempty"`
+}
+
+type derivedWalletResult struct {
+	DerivationPath string `json:"derivation_path"`
+	PublicKey      string `json:"public_key"`
+	Lamports       uint64 `json:"lamports"`
+}
+
+func (r *walletResult) print() {
+	if r.Mnemonic != "" {
+		log.Println("Mnemonic: ", r.Mnemonic)
+	}
+
+	if r.DerivationPath != "" {
+		log.Println("Derivation path: ", r.DerivationPath)
+	}
+
+	if r.Name != "" {
+		log.Println("Wallet: ", r.Name)
+	}
+
+	if r.KeypairFile != "" {
+		log.Println("Keypair file: ", r.KeypairFile)
+	}
+
+	if r.PrivateKey != "" {
+		log.Println("Private key: ", r.PrivateKey)
+	}
+
+	if r.PublicKey != "" {
+		log.Println("Public key: ", r.PublicKey)
+	}
+}
+
+func NewWalletCmd() *cobra.Command {
+	walletCmd := &cobra.Command{
+		Use:   "wallet",
+		Short: "Manage wallets",
+	}
+
+	walletCmd.AddCommand(newWalletNewCmd())
+	walletCmd.AddCommand(newWalletRecoverCmd())
+	walletCmd.AddCommand(newWalletListCmd())
+	walletCmd.AddCommand(newWalletShowCmd())
+	walletCmd.AddCommand(newWalletExportCmd())
+	walletCmd.AddCommand(newWalletRemoveCmd())
+
+	return walletCmd
+}
+
+func newWalletNewCmd() *cobra.Command {
+	var outfile string
+	var force bool
+	var mnemonic bool
+	var words int
+	var derivationPath string
+
+	newCmd := &cobra.Command{
+		Use:   "new [name]",
+		Short: "Create new wallet",
+		Long:  "Create new wallet and store it in keystore by name, write it to keypair file or print it",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wallet := solana.NewWallet()
+
+			var phrase string
+			if mnemonic {
+				var err error
+				phrase, err = hdwallet.NewMnemonic(words)
+				if err != nil {
+					return err
+				}
+
+				key, err := hdwallet.FromMnemonic(phrase, "", derivationPath)
+				if err != nil {
+					return err
+				}
+
+				wallet = &solana.Wallet{PrivateKey: key}
+			}
+
+			result, err := saveWallet(args, wallet.PrivateKey, outfile, force)
+			if err != nil {
+				return err
+			}
+
+			if mnemonic {
+				result.Mnemonic = phrase
+				result.DerivationPath = derivationPath
+			}
+
+			return PrintResult(cmd, result, result.print)
+		},
+	}
+
+	newCmd.Flags().StringVarP(&outfile, "outfile", "o", "", "Write keypair file in solana-keygen format")
+	newCmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite existing keypair file")
+	newCmd.Flags().BoolVarP(&mnemonic, "mnemonic", "m", false, "Generate BIP39 mnemonic and derive wallet from it")
+	newCmd.Flags().IntVarP(&words, "words", "", 12, "Mnemonic words count (12 or 24)")
+	newCmd.Flags().StringVarP(&derivationPath, "derivation-path", "", hdwallet.DefaultPath, "Derivation path for mnemonic wallet")
+	return newCmd
+}
+
+func newWalletRecoverCmd() *cobra.Command {
+	var outfile string
+	var force bool
+	var derivationPath string
+	var list uint32
+
+	recoverCmd := &cobra.Command{
+		Use:   "recover [name]",
+		Short: "Recover wallet from mnemonic",
+		Long:  "Derive wallet from BIP39 mnemonic, store it in keystore by name, write it to keypair file or print it",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			phrase, err := ReadMnemonic()
+			if err != nil {
+				return err
+			}
+
+			if list > 0 {
+				return listDerivedWallets(cmd, phrase, list)
+			}
+
+			key, err := hdwallet.FromMnemonic(phrase, "", derivationPath)
+			if err != nil {
+				return err
+			}
+
+			result, err := saveWallet(args, key, outfile, force)
+			if err != nil {
+				return err
+			}
+
+			result.DerivationPath = derivationPath
+			return PrintResult(cmd, result, result.print)
+		},
+	}
+
+	recoverCmd.Flags().StringVarP(&outfile, "outfile", "o", "", "Write keypair file in solana-keygen format")
+	recoverCmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite existing keypair file")
+	recoverCmd.Flags().StringVarP(&derivationPath, "derivation-path", "", hdwallet.DefaultPath, "Derivation path")
+	recoverCmd.Flags().Uint32VarP(&list, "list", "l", 0, "List first N derived addresses with balances")
+	return recoverCmd
+}
+
+func listDerivedWallets(cmd *cobra.Command, phrase string, count uint32) error {
+	seed, err := hdwallet.SeedFromMnemonic(phrase, "")
+	if err != nil {
+		return err
+	}
+
+	client, err := ClientFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results := []derivedWalletResult{}
+	for i := uint32(0); i < count; i++ {
+		path := hdwallet.AccountPath(i)
+
+		key, err := hdwallet.Derive(seed, path)
+		if err != nil {
+			return err
+		}
+
+		balance, err := client.Balance(cmd.Context(), key.PublicKey())
+		if err != nil {
+			return err
+		}
+
+		results = append(results, derivedWalletResult{
+			DerivationPath: path,
+			PublicKey:      key.PublicKey().String(),
+			Lamports:       balance,
+		})
+	}
+
+	return PrintResult(cmd, results, func() {
+		for _, result := range results {
+			log.Printf("%s\t%s\t%d lamports", result.DerivationPath, result.PublicKey, result.Lamports)
+		}
+	})
+}
+
+func saveWallet(args []string, key solana.PrivateKey, outfile string, force bool) (*walletResult, error) {
+	result := &walletResult{PublicKey: key.PublicKey().String()}
+
+	// nothing is written if keypair file can't be
+	if outfile != "" {
+		if err := checkKeypairFile(outfile, force); err != nil {
+			return nil, err
+		}
+	}
+
+	var ks *keystore.Keystore
+	if len(args) > 0 {
+		var err error
+		ks, err = defaultKeystore()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := storeWallet(ks, args[0], key); err != nil {
+			return nil, err
+		}
+
+		result.Name = args[0]
+	}
+
+	if outfile != "" {
+		if err := WriteKeypairFile(outfile, key, force); err != nil {
+			// stored wallet is removed, so command can be repeated
+			if ks != nil {
+				ks.Remove(args[0])
+			}
+			return nil, err
+		}
+
+		result.KeypairFile = outfile
+	}
+
+	if len(args) == 0 && outfile == "" {
+		result.PrivateKey = key.String()
+	}
+
+	return result, nil
+}
+
+func newWalletListCmd() *cobra.Command {
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List keystore wallets",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ks, err := defaultKeystore()
+			if err != nil {
+				return err
+			}
+
+			entries, err := ks.List()
+			if err != nil {
+				return err
+			}
+
+			results := []walletResult{}
+			for _, entry := range entries {
+				results = append(results, walletResult{Name: entry.Name, PublicKey: entry.PublicKey.String()})
+			}
+
+			return PrintResult(cmd, results, func() {
+				for _, result := range results {
+					log.Printf("%s\t%s", result.Name, result.PublicKey)
+				}
+			})
+		},
+	}
+
+	return listCmd
+}
+
+func newWalletShowCmd() *cobra.Command {
+	showCmd := &cobra.Command{
+		Use:   "show [name]",
+		Short: "Show keystore wallet public key",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ks, err := defaultKeystore()
+			if err != nil {
+				return err
+			}
+
+			entry, err := ks.Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			result := &walletResult{Name: entry.Name, PublicKey: entry.PublicKey.String()}
+			return PrintResult(cmd, result, result.print)
+		},
+	}
+
+	return showCmd
+}
+
+func newWalletExportCmd() *cobra.Command {
+	var outfile string
+	var force bool
+
+	exportCmd := &cobra.Command{
+		Use:   "export [name]",
+		Short: "Export keystore wallet private key",
+		Long:  "Decrypt keystore wallet and write it to keypair file or print private key",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := decryptWallet(args[0])
+			if err != nil {
+				return err
+			}
+
+			result := &walletResult{Name: args[0]}
+			if outfile != "" {
+				if err := WriteKeypairFile(outfile, key, force); err != nil {
+					return err
+				}
+
+				result.KeypairFile = outfile
+			} else {
+				result.PrivateKey = key.String()
+			}
+
+			return PrintResult(cmd, result, result.print)
+		},
+	}
+
+	exportCmd.Flags().StringVarP(&outfile, "outfile", "o", "", "Write keypair file in solana-keygen format")
+	exportCmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite existing keypair file")
+	return exportCmd
+}
+
+func newWalletRemoveCmd() *cobra.Command {
+	removeCmd := &cobra.Command{
+		Use:   "remove [name]",
+		Short: "Remove keystore wallet",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ks, err := defaultKeystore()
+			if err != nil {
+				return err
+			}
+
+			if err := ks.Remove(args[0]); err != nil {
+				return err
+			}
+
+			result := &walletResult{Name: args[0]}
+			return PrintResult(cmd, result, func() {
+				log.Println("Removed wallet: ", result.Name)
+			})
+		},
+	}
+
+	return removeCmd
+}
+
+func defaultKeystore() (*keystore.Keystore, error) {
+	dir, err := keystore.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return keystore.New(dir), nil
+}
+
+func storeWallet(ks *keystore.Keystore, name string, key solana.PrivateKey) error {
+	if _, err := ks.Get(name); err == nil {
+		return keystore.ErrExists
+	} else if !errors.Is(err, keystore.ErrNotFound) {
+		return err
+	}
+
+	passphrase, err := ReadPassphrase("New passphrase: ", true)
+	if err != nil {
+		return err
+	}
+
+	_, err = ks.Create(name, key, passphrase)
+	return err
+}
+
+func decryptWallet(name string) (solana.PrivateKey, error) {
+	ks, err := defaultKeystore()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ks.Get(name); err != nil {
+		return nil, err
+	}
+
+	passphrase, err := ReadPassphrase("Passphrase for "+name+": ", false)
+	if err != nil {
+		return nil, err
+	}
+
+	return ks.Decrypt(name, passphrase)
+}
@@ -1,9 +1,15 @@
 package main
 
-import cmd "solana/cmd/commands"
+import (
+	"os"
+	cmd "solana/cmd/commands"
+)
 
 func main() {
 	root := cmd.NewRootCmd()
 
-	root.Execute()
+	if err := root.Execute(); err != nil {
+		cmd.PrintError(root, err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Max accounts of one getMultipleAccounts call
+const maxMultipleAccounts = 100
+
+// Fetch accounts missing in cache with batched getMultipleAccounts calls,
+// accounts are cached for the lifetime of client
+func (c *Client) PrefetchAccounts(ctx context.Context, keys ...solana.PublicKey) error {
+	c.accountsMu.Lock()
+	missing := make([]solana.PublicKey, 0, len(keys))
+	seen := make(map[solana.PublicKey]bool, len(keys))
+	for _, key := range keys {
+		if _, ok := c.accounts[key]; ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+		missing = append(missing, key)
+	}
+	c.accountsMu.Unlock()
+
+	for start := 0; start < len(missing); start += maxMultipleAccounts {
+		end := start + maxMultipleAccounts
+		if end > len(missing) {
+			end = len(missing)
+		}
+
+		out, err := c.rpc.GetMultipleAccountsWithOpts(ctx, missing[start:end], &rpc.GetMultipleAccountsOpts{
+			Encoding:   solana.EncodingBase64,
+			Commitment: c.commitment,
+		})
+		if err != nil {
+			return err
+		}
+
+		c.accountsMu.Lock()
+		for i, account := range out.Value {
+			c.accounts[missing[start+i]] = account
+		}
+		c.accountsMu.Unlock()
+	}
+
+	return nil
+}
+
+// Get accounts from cache or fetch them, missing accounts are nil
+func (c *Client) Accounts(ctx context.Context, keys ...solana.PublicKey) ([]*rpc.Account, error) {
+	if err := c.PrefetchAccounts(ctx, keys...); err != nil {
+		return nil, err
+	}
+
+	c.accountsMu.Lock()
+	defer c.accountsMu.Unlock()
+
+	accounts := make([]*rpc.Account, len(keys))
+	for i, key := range keys {
+		accounts[i] = c.accounts[key]
+	}
+
+	return accounts, nil
+}
+
+// Get account from cache or fetch it, missing account is nil
+func (c *Client) Account(ctx context.Context, key solana.PublicKey) (*rpc.Account, error) {
+	accounts, err := c.Accounts(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts[0], nil
+}
+
+// Fetch associated token accounts of owner for mints in one batch
+func (c *Client) prefetchTokenAccounts(ctx context.Context, owner solana.PublicKey, mints ...solana.PublicKey) error {
+	accounts := make([]solana.PublicKey, 0, len(mints))
+	for _, mint := range mints {
+		account, _, err := solana.FindAssociatedTokenAddress(owner, mint)
+		if err != nil {
+			return err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return c.PrefetchAccounts(ctx, accounts...)
+}
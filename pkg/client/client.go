@@ -2,81 +2,331 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"solana/pkg/instructions"
 	"solana/pkg/model"
+	"solana/pkg/stableswap"
+	"sync"
+	"time"
 
+	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
 	a "github.com/gagliardetto/solana-go/programs/associated-token-account"
 	"github.com/gagliardetto/solana-go/rpc"
-	confirm "github.com/gagliardetto/solana-go/rpc/sendAndConfirmTransaction"
 	"github.com/gagliardetto/solana-go/rpc/ws"
 )
 
+// Result of instruction sending
+type SendResult struct {
+	Signature   solana.Signature
+	Accounts    []instructions.NamedAccount
+	Simulation  *Simulation
+	PriorityFee uint64
+	// Serialized transaction in sign only and dump modes
+	Transaction string
+	// Signers without signature in sign only and dump modes
+	MissingSigners []solana.PublicKey
+	// Last block height transaction can be confirmed at, zero for nonce transaction
+	LastValidBlockHeight uint64
+	// Final status of sent transaction
+	Status string
+	// Program error of failed transaction
+	Err interface{}
+	// Sends of transaction
+	Attempts int
+}
+
+// Options of instruction sending
+type SendOptions struct {
+	// Return instruction accounts without sending
+	ShowAccounts bool
+	// Simulate signed transaction without sending
+	Simulate bool
+	// Compute unit limit, zero keeps default limit
+	ComputeUnitLimit uint32
+	// Compute unit price in micro-lamports, zero doesn't set price
+	PriorityFee uint64
+	// Take priority fee from recent fees paid for writable accounts
+	AutoPriorityFee bool
+	// Percentile of recent fees for auto priority fee
+	PriorityFeePercentile int
+	// Keys to sign transaction with
+	Signers []solana.PrivateKey
+	// Sign with available signers and return transaction without sending
+	SignOnly bool
+	// Return unsigned transaction without sending
+	DumpTransaction bool
+	// Encoding of returned transaction, base64 by default
+	Encoding string
+	// Blockhash to use instead of recent one
+	Blockhash *solana.Hash
+	// Durable nonce account to use stored nonce as blockhash
+	Nonce *solana.PublicKey
+	// Nonce authority, payer by default
+	NonceAuthority *solana.PublicKey
+	// Interval of transaction rebroadcast
+	RebroadcastInterval time.Duration
+	// Max sends of transaction, zero sends until expiration
+	MaxAttempts int
+	// Max re-signs of expired transaction with new blockhash
+	Resigns int
+}
+
+// Client options
+type ClientOptions struct {
+	// Commitment of reads, finalized by default
+	Commitment rpc.CommitmentType
+	// Commitment to wait for on confirmation, read commitment by default
+	ConfirmCommitment rpc.CommitmentType
+	// Confirm transactions by polling signature statuses instead of websocket subscription
+	PollConfirmation bool
+	// Backup endpoints in order of preference
+	Backups []rpc.Cluster
+	// Requests per second to each endpoint, zero doesn't limit
+	RateLimit float64
+	// Requests over rate limit allowed at once
+	RateBurst int
+	// Retries of rate limited and server failed requests
+	MaxRetries int
+}
+
 type Client struct {
-	rpc *rpc.Client
-	ws  *ws.Client
+	rpc       *rpc.Client
+	endpoints *failoverRPC
+
+	// websocket is connected on first subscription
+	wsMu      sync.Mutex
+	ws        *ws.Client
+	wsLimiter *rateLimiter
+
+	// accounts fetched by client, nil for missing accounts
+	accountsMu sync.Mutex
+	accounts   map[solana.PublicKey]*rpc.Account
+
+	commitment        rpc.CommitmentType
+	confirmCommitment rpc.CommitmentType
+	pollConfirmation  bool
 }
 
-func NewClient(ctx context.Context, cluster rpc.Cluster) (*Client, error) {
-	rpc := rpc.New(cluster.RPC)
-	ws, err := ws.Connect(ctx, cluster.WS)
-	if err != nil {
-		return nil, err
+func NewClient(ctx context.Context, cluster rpc.Cluster, opts *ClientOptions) (*Client, error) {
+	if opts == nil {
+		opts = &ClientOptions{}
 	}
 
+	commitment := opts.Commitment
+	if commitment == "" {
+		commitment = rpc.CommitmentFinalized
+	}
+
+	confirmCommitment := opts.ConfirmCommitment
+	if confirmCommitment == "" {
+		confirmCommitment = commitment
+	}
+
+	endpoints := newFailoverRPC(append([]rpc.Cluster{cluster}, opts.Backups...), opts)
+
 	return &Client{
-		rpc: rpc,
-		ws:  ws,
+		rpc:               rpc.NewWithCustomRPCClient(endpoints),
+		endpoints:         endpoints,
+		accounts:          map[solana.PublicKey]*rpc.Account{},
+		commitment:        commitment,
+		confirmCommitment: confirmCommitment,
+		pollConfirmation:  opts.PollConfirmation,
 	}, nil
 }
 
 func (c *Client) Close() {
-	c.ws.Close()
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+
+	if c.ws != nil {
+		c.ws.Close()
+		c.ws = nil
+	}
 }
 
-func (c *Client) Recent(ctx context.Context) (solana.Hash, error) {
-	r, err := c.rpc.GetRecentBlockhash(ctx, rpc.CommitmentFinalized)
+// Get websocket client with rate limiter of its endpoint,
+// connect on first call starting from websocket of active endpoint
+func (c *Client) websocket(ctx context.Context) (*ws.Client, *rateLimiter, error) {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+
+	if c.ws != nil {
+		return c.ws, c.wsLimiter, nil
+	}
+
+	count := len(c.endpoints.endpoints)
+	start := c.endpoints.activeIndex()
+
+	var err error
+	for i := 0; i < count; i++ {
+		idx := (start + i) % count
+
+		var conn *ws.Client
+		conn, err = c.endpoints.connectWebsocket(ctx, idx)
+		if err == nil {
+			c.ws = conn
+			c.wsLimiter = c.endpoints.limiters[idx]
+			return conn, c.wsLimiter, nil
+		}
+	}
+
+	return nil, nil, err
+}
+
+// Get latest blockhash and last block height it is valid at
+func (c *Client) Recent(ctx context.Context) (solana.Hash, uint64, error) {
+	r, err := c.rpc.GetLatestBlockhash(ctx, c.commitment)
 	if err != nil {
-		return solana.Hash{}, err
+		return solana.Hash{}, 0, err
 	}
 
-	return r.Value.Blockhash, nil
+	return r.Value.Blockhash, r.Value.LastValidBlockHeight, nil
 }
 
-func (c *Client) SendInstructions(ctx context.Context, instr []solana.Instruction, wallet *solana.Wallet) (solana.Signature, error) {
+// Sign and send instructions paid by payer, watch token accounts are reported on simulation
+func (c *Client) SendInstructions(ctx context.Context, instr []solana.Instruction, payer solana.PublicKey, opts *SendOptions, watch ...solana.PublicKey) (*SendResult, error) {
+	if opts == nil {
+		opts = &SendOptions{}
+	}
+
+	if opts.ShowAccounts {
+		return &SendResult{Accounts: instructions.InstructionAccounts(instr)}, nil
+	}
 
-	recent, err := c.Recent(ctx)
+	budget, priorityFee, err := c.ComputeBudget(ctx, instr, opts)
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
+	}
+	instr = append(budget, instr...)
+
+	var recent solana.Hash
+	var lastValidBlockHeight uint64
+	if opts.Nonce != nil {
+		authority := payer
+		if opts.NonceAuthority != nil {
+			authority = *opts.NonceAuthority
+		}
+
+		// advance nonce must be the first instruction
+		advance, nonce, err := c.nonceBlockhash(ctx, *opts.Nonce, authority)
+		if err != nil {
+			return nil, err
+		}
+		instr = append([]solana.Instruction{advance}, instr...)
+		recent = nonce
+	} else if opts.Blockhash != nil {
+		recent = *opts.Blockhash
+	} else {
+		recent, lastValidBlockHeight, err = c.Recent(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	tx, err := solana.NewTransaction(
-		instr, recent, solana.TransactionPayer(wallet.PublicKey()),
+		instr, recent, solana.TransactionPayer(payer),
 	)
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
 	}
 
-	_, err = tx.Sign(
-		func(key solana.PublicKey) *solana.PrivateKey {
-			if wallet.PublicKey().Equals(key) {
-				return &wallet.PrivateKey
-			}
-			return nil
-		},
-	)
+	if !opts.DumpTransaction {
+		if err := PartialSign(tx, opts.Signers); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.DumpTransaction || opts.SignOnly {
+		res, err := offlineResult(tx, opts.Encoding)
+		if err != nil {
+			return nil, err
+		}
+
+		res.PriorityFee = priorityFee
+		res.LastValidBlockHeight = lastValidBlockHeight
+		return res, nil
+	}
+
+	res, err := c.SendTransaction(ctx, tx, lastValidBlockHeight, opts, watch...)
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
 	}
 
-	sig, err := confirm.SendAndConfirmTransaction(
-		ctx, c.rpc, c.ws, tx,
-	)
+	res.PriorityFee = priorityFee
+	return res, nil
+}
+
+// Send signed transaction and rebroadcast it until final status or simulate it,
+// zero last valid block height checks expiration by transaction blockhash
+func (c *Client) SendTransaction(ctx context.Context, tx *solana.Transaction, lastValidBlockHeight uint64, opts *SendOptions, watch ...solana.PublicKey) (*SendResult, error) {
+	if missing := MissingSigners(tx); len(missing) > 0 {
+		return nil, fmt.Errorf("transaction is not signed by %s", missing[0])
+	}
+
+	if err := tx.VerifySignatures(); err != nil {
+		return nil, err
+	}
+
+	if opts != nil && opts.Simulate {
+		sim, err := c.Simulate(ctx, tx, watch)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SendResult{Signature: tx.Signatures[0], Simulation: sim}, nil
+	}
+
+	return c.NewSender(opts).Send(ctx, tx, lastValidBlockHeight)
+}
+
+func offlineResult(tx *solana.Transaction, encoding string) (*SendResult, error) {
+	if encoding == "" {
+		encoding = EncodingBase64
+	}
+
+	content, err := EncodeTransaction(tx, encoding)
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
 	}
 
-	return sig, nil
+	return &SendResult{
+		Signature:      tx.Signatures[0],
+		Transaction:    content,
+		MissingSigners: MissingSigners(tx),
+	}, nil
+}
+
+// Get compute budget instructions and priority fee for instructions
+func (c *Client) ComputeBudget(ctx context.Context, instr []solana.Instruction, opts *SendOptions) ([]solana.Instruction, uint64, error) {
+	budget := []solana.Instruction{}
+
+	if opts.ComputeUnitLimit > 0 {
+		limit, err := instructions.NewComputeUnitLimit(opts.ComputeUnitLimit)
+		if err != nil {
+			return nil, 0, err
+		}
+		budget = append(budget, limit)
+	}
+
+	priorityFee := opts.PriorityFee
+	if opts.AutoPriorityFee {
+		fee, err := c.PriorityFee(ctx, writableAccounts(instr), opts.PriorityFeePercentile)
+		if err != nil {
+			return nil, 0, err
+		}
+		priorityFee = fee
+	}
+
+	if priorityFee > 0 {
+		price, err := instructions.NewComputeUnitPrice(priorityFee)
+		if err != nil {
+			return nil, 0, err
+		}
+		budget = append(budget, price)
+	}
+
+	return budget, priorityFee, nil
 }
 
 func (c *Client) GetTokenAccount(ctx context.Context, pubKey solana.PublicKey, mint solana.PublicKey) (solana.PublicKey, *a.Instruction, error) {
@@ -87,22 +337,24 @@ func (c *Client) GetTokenAccount(ctx context.Context, pubKey solana.PublicKey, m
 	}
 
 	// find account
-	_, err = c.rpc.GetAccountInfo(ctx, account)
-	if err != nil && err.Error() == "not found" {
+	info, err := c.Account(ctx, account)
+	if err != nil {
+		return account, nil, err
+	}
+
+	if info == nil {
 		instr, err := a.NewCreateInstruction(pubKey, pubKey, mint).ValidateAndBuild()
 		if err != nil {
 			return account, nil, err
 		}
 		return account, instr, err
-	} else if err != nil {
-		return account, nil, err
-	} else {
-		return account, nil, err
 	}
+
+	return account, nil, nil
 }
 
 func (c *Client) Airdrop(ctx context.Context, pubKey solana.PublicKey, sol uint64) (solana.Signature, error) {
-	sig, err := c.rpc.RequestAirdrop(ctx, pubKey, sol*solana.LAMPORTS_PER_SOL, rpc.CommitmentFinalized)
+	sig, err := c.rpc.RequestAirdrop(ctx, pubKey, sol*solana.LAMPORTS_PER_SOL, c.commitment)
 	if err != nil {
 		return solana.Signature{}, err
 	}
@@ -111,7 +363,7 @@ func (c *Client) Airdrop(ctx context.Context, pubKey solana.PublicKey, sol uint6
 }
 
 func (c *Client) Balance(ctx context.Context, pubKey solana.PublicKey) (uint64, error) {
-	out, err := c.rpc.GetBalance(ctx, pubKey, rpc.CommitmentFinalized)
+	out, err := c.rpc.GetBalance(ctx, pubKey, c.commitment)
 	if err != nil {
 		return 0, err
 	}
@@ -120,69 +372,162 @@ func (c *Client) Balance(ctx context.Context, pubKey solana.PublicKey) (uint64,
 }
 
 func (c *Client) SwapInfo(ctx context.Context, account solana.PublicKey) (*model.SwapInfo, error) {
+	info, err := c.Account(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	if info == nil {
+		return nil, fmt.Errorf("swap account %s: %w", account, rpc.ErrNotFound)
+	}
+
 	var swapInfo model.SwapInfo
-	err := c.rpc.GetAccountDataInto(ctx, account, &swapInfo)
+	err = bin.NewBinDecoder(info.Data.GetBinary()).Decode(&swapInfo)
 	if err != nil {
 		return nil, err
 	}
 	return &swapInfo, nil
 }
 
+func (c *Client) Quote(ctx context.Context, swapAccount, tokenA, tokenB solana.PublicKey, amount uint64) (*stableswap.SwapResult, error) {
+	swapInfo, err := c.SwapInfo(ctx, swapAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenA.Equals(tokenB) {
+		return nil, fmt.Errorf("cann't quote swap of token %s to itself", tokenA)
+	}
+
+	pairToken, err := swapInfo.PairToken(tokenA)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pairToken.TokenMint.Equals(tokenB) {
+		return nil, fmt.Errorf("token %s isn't pair of token %s in swap", tokenB, tokenA)
+	}
+
+	balances, err := c.PoolBalances(ctx, swapInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := c.BlockTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reserveA, reserveB := balances.Reserves(swapInfo, tokenA)
+	return stableswap.New(swapInfo, ts).SwapTo(amount, reserveA, reserveB)
+}
+
+func (c *Client) WithdrawQuote(ctx context.Context, swapAccount solana.PublicKey, poolTokenAmount uint64) (*stableswap.WithdrawResult, error) {
+	swapInfo, err := c.SwapInfo(ctx, swapAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := c.PoolBalances(ctx, swapInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := c.BlockTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return stableswap.New(swapInfo, ts).Withdraw(poolTokenAmount, balances.PoolTokenSupply, balances.ReserveA, balances.ReserveB)
+}
+
+func (c *Client) WithdrawOneQuote(ctx context.Context, swapAccount, token solana.PublicKey, poolTokenAmount uint64) (*stableswap.WithdrawOneResult, error) {
+	swapInfo, err := c.SwapInfo(ctx, swapAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := swapInfo.HasToken(token); err != nil {
+		return nil, err
+	}
+
+	balances, err := c.PoolBalances(ctx, swapInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := c.BlockTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	baseReserve, quoteReserve := balances.Reserves(swapInfo, token)
+	return stableswap.New(swapInfo, ts).WithdrawOne(poolTokenAmount, balances.PoolTokenSupply, baseReserve, quoteReserve)
+}
+
+func SwapAuthority(programId, swapAccount solana.PublicKey, swapInfo *model.SwapInfo) (solana.PublicKey, error) {
+	return solana.CreateProgramAddress([][]byte{swapAccount.Bytes(), {swapInfo.Nonce}}, programId)
+}
+
 func (c *Client) Swap(ctx context.Context,
 	programId, swapAccount, tokenA, tokenB solana.PublicKey,
-	wallet *solana.Wallet,
+	owner solana.PublicKey,
 	swapData *instructions.SwapData,
-	showAccounts bool) (solana.Signature, error) {
+	opts *SendOptions) (*SendResult, error) {
 
 	instrs := []solana.Instruction{}
 
 	swapInfo, err := c.SwapInfo(ctx, swapAccount)
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
 	}
 
 	swapTokenA, err := swapInfo.HasToken(tokenA)
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
 	}
 
 	swapTokenB, err := swapInfo.HasToken(tokenB)
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
 	}
 
-	swapAuthority, err := solana.CreateProgramAddress([][]byte{swapAccount.Bytes(), {swapInfo.Nonce}}, programId)
+	swapAuthority, err := SwapAuthority(programId, swapAccount, swapInfo)
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
+	}
+
+	if err := c.prefetchTokenAccounts(ctx, owner, swapTokenA.TokenMint, swapTokenB.TokenMint); err != nil {
+		return nil, err
 	}
 
-	userTokenA, instrTokenA, err := c.GetTokenAccount(ctx, wallet.PublicKey(), swapTokenA.TokenMint)
+	userTokenA, instrTokenA, err := c.GetTokenAccount(ctx, owner, swapTokenA.TokenMint)
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
 	}
 
 	if instrTokenA != nil {
 		instrs = append(instrs, instrTokenA)
 	}
 
-	userTokenB, instrTokenB, err := c.GetTokenAccount(ctx, wallet.PublicKey(), swapTokenB.TokenMint)
+	userTokenB, instrTokenB, err := c.GetTokenAccount(ctx, owner, swapTokenB.TokenMint)
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
 	}
 
 	if instrTokenB != nil {
-		instrs = append(instrs, instrTokenA)
+		instrs = append(instrs, instrTokenB)
 	}
 
 	bytes, err := swapData.GetBytes()
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
 	}
 
 	swap := instructions.NewSwap(programId).
 		SetSwapAccount(swapAccount).
 		SetAuthority(swapAuthority).
-		SetUserAuthority(wallet.PublicKey()).
+		SetUserAuthority(owner).
 		SetUserSource(userTokenA).
 		SetPoolSource(swapTokenA.TokenReserve).
 		SetPoolDestination(swapTokenB.TokenReserve).
@@ -190,22 +535,239 @@ func (c *Client) Swap(ctx context.Context,
 		SetAdminDestination(swapTokenB.TokenFee).
 		SetData(bytes)
 
-	if showAccounts {
-		swap.ShowAccounts()
-		return solana.Signature{}, nil
+	if opts != nil && opts.ShowAccounts {
+		return &SendResult{Accounts: swap.Accounts()}, nil
 	}
 
 	swapInstr, err := swap.Build()
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
 	}
 
 	instrs = append(instrs, swapInstr)
-	sig, err := c.SendInstructions(ctx, instrs, wallet)
+	return c.SendInstructions(ctx, instrs, owner, opts, userTokenA, userTokenB)
+}
+
+func (c *Client) Deposit(ctx context.Context,
+	programId, swapAccount solana.PublicKey,
+	owner solana.PublicKey,
+	depositData *instructions.DepositData,
+	opts *SendOptions) (*SendResult, error) {
+
+	instrs := []solana.Instruction{}
+
+	swapInfo, err := c.SwapInfo(ctx, swapAccount)
 	if err != nil {
-		return solana.Signature{}, err
+		return nil, err
 	}
 
-	return sig, nil
+	swapAuthority, err := SwapAuthority(programId, swapAccount, swapInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.prefetchTokenAccounts(ctx, owner, swapInfo.TokenAMint, swapInfo.TokenBMint, swapInfo.PoolTokenMint); err != nil {
+		return nil, err
+	}
+
+	userTokenA, _, err := c.GetTokenAccount(ctx, owner, swapInfo.TokenAMint)
+	if err != nil {
+		return nil, err
+	}
+
+	userTokenB, _, err := c.GetTokenAccount(ctx, owner, swapInfo.TokenBMint)
+	if err != nil {
+		return nil, err
+	}
+
+	userPoolToken, instrPoolToken, err := c.GetTokenAccount(ctx, owner, swapInfo.PoolTokenMint)
+	if err != nil {
+		return nil, err
+	}
+
+	if instrPoolToken != nil {
+		instrs = append(instrs, instrPoolToken)
+	}
+
+	bytes, err := depositData.GetBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	deposit := instructions.NewDeposit(programId).
+		SetSwapAccount(swapAccount).
+		SetAuthority(swapAuthority).
+		SetUserAuthority(owner).
+		SetUserSourceA(userTokenA).
+		SetUserSourceB(userTokenB).
+		SetPoolDestinationA(swapInfo.TokenAReserve).
+		SetPoolDestinationB(swapInfo.TokenBReserve).
+		SetPoolMint(swapInfo.PoolTokenMint).
+		SetUserPoolDestination(userPoolToken).
+		SetData(bytes)
+
+	if opts != nil && opts.ShowAccounts {
+		return &SendResult{Accounts: deposit.Accounts()}, nil
+	}
+
+	depositInstr, err := deposit.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	instrs = append(instrs, depositInstr)
+	return c.SendInstructions(ctx, instrs, owner, opts, userTokenA, userTokenB, userPoolToken)
+}
+
+func (c *Client) Withdraw(ctx context.Context,
+	programId, swapAccount solana.PublicKey,
+	owner solana.PublicKey,
+	withdrawData *instructions.WithdrawData,
+	opts *SendOptions) (*SendResult, error) {
+
+	instrs := []solana.Instruction{}
+
+	swapInfo, err := c.SwapInfo(ctx, swapAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	swapAuthority, err := SwapAuthority(programId, swapAccount, swapInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.prefetchTokenAccounts(ctx, owner, swapInfo.PoolTokenMint, swapInfo.TokenAMint, swapInfo.TokenBMint); err != nil {
+		return nil, err
+	}
+
+	userPoolToken, _, err := c.GetTokenAccount(ctx, owner, swapInfo.PoolTokenMint)
+	if err != nil {
+		return nil, err
+	}
+
+	userTokenA, instrTokenA, err := c.GetTokenAccount(ctx, owner, swapInfo.TokenAMint)
+	if err != nil {
+		return nil, err
+	}
+
+	if instrTokenA != nil {
+		instrs = append(instrs, instrTokenA)
+	}
+
+	userTokenB, instrTokenB, err := c.GetTokenAccount(ctx, owner, swapInfo.TokenBMint)
+	if err != nil {
+		return nil, err
+	}
+
+	if instrTokenB != nil {
+		instrs = append(instrs, instrTokenB)
+	}
+
+	bytes, err := withdrawData.GetBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	withdraw := instructions.NewWithdraw(programId).
+		SetSwapAccount(swapAccount).
+		SetAuthority(swapAuthority).
+		SetUserAuthority(owner).
+		SetPoolMint(swapInfo.PoolTokenMint).
+		SetUserPoolSource(userPoolToken).
+		SetPoolSourceA(swapInfo.TokenAReserve).
+		SetPoolSourceB(swapInfo.TokenBReserve).
+		SetUserDestinationA(userTokenA).
+		SetUserDestinationB(userTokenB).
+		SetAdminDestinationA(swapInfo.TokenAFee).
+		SetAdminDestinationB(swapInfo.TokenBFee).
+		SetData(bytes)
+
+	if opts != nil && opts.ShowAccounts {
+		return &SendResult{Accounts: withdraw.Accounts()}, nil
+	}
+
+	withdrawInstr, err := withdraw.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	instrs = append(instrs, withdrawInstr)
+	return c.SendInstructions(ctx, instrs, owner, opts, userPoolToken, userTokenA, userTokenB)
+}
+
+func (c *Client) WithdrawOne(ctx context.Context,
+	programId, swapAccount, token solana.PublicKey,
+	owner solana.PublicKey,
+	withdrawData *instructions.WithdrawOneData,
+	opts *SendOptions) (*SendResult, error) {
+
+	instrs := []solana.Instruction{}
+
+	swapInfo, err := c.SwapInfo(ctx, swapAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	baseToken, err := swapInfo.HasToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	quoteToken, err := swapInfo.PairToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	swapAuthority, err := SwapAuthority(programId, swapAccount, swapInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.prefetchTokenAccounts(ctx, owner, swapInfo.PoolTokenMint, baseToken.TokenMint); err != nil {
+		return nil, err
+	}
+
+	userPoolToken, _, err := c.GetTokenAccount(ctx, owner, swapInfo.PoolTokenMint)
+	if err != nil {
+		return nil, err
+	}
+
+	userToken, instrToken, err := c.GetTokenAccount(ctx, owner, baseToken.TokenMint)
+	if err != nil {
+		return nil, err
+	}
+
+	if instrToken != nil {
+		instrs = append(instrs, instrToken)
+	}
+
+	bytes, err := withdrawData.GetBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	withdraw := instructions.NewWithdrawOne(programId).
+		SetSwapAccount(swapAccount).
+		SetAuthority(swapAuthority).
+		SetUserAuthority(owner).
+		SetPoolMint(swapInfo.PoolTokenMint).
+		SetUserPoolSource(userPoolToken).
+		SetPoolBaseSource(baseToken.TokenReserve).
+		SetPoolQuoteSource(quoteToken.TokenReserve).
+		SetUserDestination(userToken).
+		SetAdminDestination(baseToken.TokenFee).
+		SetData(bytes)
+
+	if opts != nil && opts.ShowAccounts {
+		return &SendResult{Accounts: withdraw.Accounts()}, nil
+	}
+
+	withdrawInstr, err := withdraw.Build()
+	if err != nil {
+		return nil, err
+	}
 
+	instrs = append(instrs, withdrawInstr)
+	return c.SendInstructions(ctx, instrs, owner, opts, userPoolToken, userToken)
 }
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"solana/pkg/model"
+	"solana/pkg/stableswap"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Get account with data
+func testAccount(t *testing.T, data []byte) *rpc.Account {
+	wrapped, err := rpc.DataBytesOrJSONFromBase64(base64.StdEncoding.EncodeToString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &rpc.Account{Data: wrapped}
+}
+
+// Get account data with little endian amount at offset
+func amountData(offset int, amount uint64) []byte {
+	data := make([]byte, offset+8)
+	binary.LittleEndian.PutUint64(data[offset:], amount)
+	return data
+}
+
+// Get client with cached swap, reserve, mint and clock accounts
+func testQuoteClient(t *testing.T) (*Client, solana.PublicKey, *model.SwapInfo) {
+	swapAccount := solana.NewWallet().PublicKey()
+	info := &model.SwapInfo{
+		IsInitialized:    true,
+		InitialAmpFactor: 100,
+		TargetAmpFactor:  100,
+		TokenAReserve:    solana.NewWallet().PublicKey(),
+		TokenBReserve:    solana.NewWallet().PublicKey(),
+		PoolTokenMint:    solana.NewWallet().PublicKey(),
+		TokenAMint:       solana.NewWallet().PublicKey(),
+		TokenBMint:       solana.NewWallet().PublicKey(),
+		Fees: &model.Fees{
+			AdminTradeFeeNumerator:      50,
+			AdminTradeFeeDenominator:    100,
+			AdminWithdrawFeeNumerator:   50,
+			AdminWithdrawDeeDenominator: 100,
+			TradeFeeNumerator:           4,
+			TradeFeeDenominator:         10000,
+			WithdrawFeeNumerator:        50,
+			WithdrawFeeDenominator:      10000,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := bin.NewBinEncoder(&buf).Encode(info); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Client{accounts: map[solana.PublicKey]*rpc.Account{
+		swapAccount:              testAccount(t, buf.Bytes()),
+		info.TokenAReserve:       testAccount(t, amountData(tokenAmountOffset, 1_000_000_000)),
+		info.TokenBReserve:       testAccount(t, amountData(tokenAmountOffset, 3_000_000_000)),
+		info.PoolTokenMint:       testAccount(t, amountData(mintSupplyOffset, 4_000_000_000)),
+		solana.SysVarClockPubkey: testAccount(t, amountData(clockTimestampOffset, 1_700_000_000)),
+	}}
+
+	return c, swapAccount, info
+}
+
+func TestQuote(t *testing.T) {
+	c, swapAccount, info := testQuoteClient(t)
+
+	tests := []struct {
+		tokenA, tokenB        solana.PublicKey
+		reserveIn, reserveOut uint64
+	}{
+		{info.TokenAMint, info.TokenBMint, 1_000_000_000, 3_000_000_000},
+		{info.TokenBMint, info.TokenAMint, 3_000_000_000, 1_000_000_000},
+	}
+
+	for _, tt := range tests {
+		got, err := c.Quote(context.Background(), swapAccount, tt.tokenA, tt.tokenB, 1_000_000)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, err := stableswap.New(info, 1_700_000_000).SwapTo(1_000_000, tt.reserveIn, tt.reserveOut)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if *got != *want {
+			t.Errorf("Quote = %+v, want %+v", *got, *want)
+		}
+	}
+}
+
+func TestQuoteTokenErrors(t *testing.T) {
+	c, swapAccount, info := testQuoteClient(t)
+	other := solana.NewWallet().PublicKey()
+
+	tests := []struct {
+		name           string
+		tokenA, tokenB solana.PublicKey
+	}{
+		{"same token a", info.TokenAMint, info.TokenAMint},
+		{"same token b", info.TokenBMint, info.TokenBMint},
+		{"foreign token a", other, info.TokenBMint},
+		{"foreign token b", info.TokenAMint, other},
+	}
+
+	for _, tt := range tests {
+		if _, err := c.Quote(context.Background(), swapAccount, tt.tokenA, tt.tokenB, 1_000_000); err == nil {
+			t.Errorf("%s: Quote succeeded, want error", tt.name)
+		}
+	}
+}
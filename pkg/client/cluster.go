@@ -2,10 +2,17 @@ package client
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/gagliardetto/solana-go/rpc"
 )
 
+// Name of cluster from custom url
+const CustomCluster = "custom"
+
 func ClusterFromString(cluster string) (rpc.Cluster, error) {
 	switch cluster {
 	case "main":
@@ -16,18 +23,63 @@ func ClusterFromString(cluster string) (rpc.Cluster, error) {
 		return rpc.TestNet, nil
 	case "local":
 		return rpc.LocalNet, nil
+	}
+
+	if strings.HasPrefix(cluster, "http://") || strings.HasPrefix(cluster, "https://") {
+		return ClusterFromUrl(cluster)
+	}
+
+	return rpc.Cluster{}, fmt.Errorf("cann't parse cluster flag - %s", cluster)
+}
+
+func ClusterFromUrl(rpcUrl string) (rpc.Cluster, error) {
+	wsUrl, err := WsUrlFromRpcUrl(rpcUrl)
+	if err != nil {
+		return rpc.Cluster{}, err
+	}
+
+	return rpc.Cluster{
+		Name: CustomCluster,
+		RPC:  rpcUrl,
+		WS:   wsUrl,
+	}, nil
+}
+
+// Get websocket url from rpc url: http -> ws, https -> wss and
+// explicit port is incremented by one as solana validator does
+func WsUrlFromRpcUrl(rpcUrl string) (string, error) {
+	u, err := url.Parse(rpcUrl)
+	if err != nil {
+		return "", err
+	}
+
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
 	default:
-		return rpc.Cluster{}, fmt.Errorf("cann't parse cluster flag - %s", cluster)
+		return "", fmt.Errorf("cann't parse rpc url scheme - %s", rpcUrl)
 	}
+
+	if port := u.Port(); port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return "", err
+		}
+		u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(p+1))
+	}
+
+	return u.String(), nil
 }
 
 func SwapUrlFromCluster(cluster rpc.Cluster) (string, error) {
-	switch cluster {
-	case rpc.MainNetBeta:
+	switch cluster.Name {
+	case rpc.MainNetBeta.Name:
 		return "https://registry.saber.so/data/pools-info.mainnet.json", nil
-	case rpc.DevNet:
+	case rpc.DevNet.Name:
 		return "https://registry.saber.so/data/pools-info.devnet.json", nil
 	default:
-		return "", fmt.Errorf("cann't find url on cluster %s", cluster.Name)
+		return "", fmt.Errorf("cann't find url on cluster %s, set registry url", cluster.Name)
 	}
 }
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Parse commitment level, empty level gives empty commitment
+func ParseCommitment(level string) (rpc.CommitmentType, error) {
+	switch commitment := rpc.CommitmentType(level); commitment {
+	case "", rpc.CommitmentProcessed, rpc.CommitmentConfirmed, rpc.CommitmentFinalized:
+		return commitment, nil
+	default:
+		return "", fmt.Errorf("unknown commitment %s, use processed, confirmed or finalized", level)
+	}
+}
+
+// Check that confirmation status satisfies commitment
+func reachedCommitment(status rpc.ConfirmationStatusType, commitment rpc.CommitmentType) bool {
+	switch commitment {
+	case rpc.CommitmentProcessed:
+		return status != ""
+	case rpc.CommitmentConfirmed:
+		return status == rpc.ConfirmationStatusConfirmed || status == rpc.ConfirmationStatusFinalized
+	default:
+		return status == rpc.ConfirmationStatusFinalized
+	}
+}
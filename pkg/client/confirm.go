@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+// Transaction statuses
+const (
+	StatusConfirmed = "confirmed"
+	StatusFailed    = "failed"
+	StatusExpired   = "expired"
+)
+
+// Check of transaction expiration, nil check never expires
+type expiryCheck func(ctx context.Context) (bool, error)
+
+// Get expiration check of transaction: by last valid block height if it is known,
+// by validity of transaction blockhash otherwise. Nonce transaction doesn't expire.
+func (c *Client) txExpiry(tx *solana.Transaction, lastValidBlockHeight uint64) expiryCheck {
+	if lastValidBlockHeight > 0 {
+		return c.heightExpiry(lastValidBlockHeight)
+	}
+
+	if isNonceTransaction(tx) {
+		return nil
+	}
+
+	blockhash := tx.Message.RecentBlockhash
+	return func(ctx context.Context) (bool, error) {
+		// processed bank knows recent blockhashes which aren't finalized yet
+		out, err := c.rpc.IsBlockhashValid(ctx, blockhash, rpc.CommitmentProcessed)
+		if err != nil {
+			return false, err
+		}
+
+		return !out.Value, nil
+	}
+}
+
+// Get expiration check by block height, zero last valid block height never expires
+func (c *Client) heightExpiry(lastValidBlockHeight uint64) expiryCheck {
+	if lastValidBlockHeight == 0 {
+		return nil
+	}
+
+	return func(ctx context.Context) (bool, error) {
+		height, err := c.rpc.GetBlockHeight(ctx, c.confirmCommitment)
+		if err != nil {
+			return false, err
+		}
+
+		return height > lastValidBlockHeight, nil
+	}
+}
+
+// Wait for final transaction status, tick is called on every interval.
+// Without websocket status is polled on every interval.
+func (c *Client) waitStatus(ctx context.Context, sig solana.Signature, expired expiryCheck, interval time.Duration, tick func()) (string, interface{}, error) {
+	results, errs, unsubscribe := c.subscribeSignature(ctx, sig)
+	defer unsubscribe()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", nil, ctx.Err()
+		case <-errs:
+			// websocket is lost, poll status instead
+			results, errs = nil, nil
+		case got := <-results:
+			if got.Value.Err != nil {
+				return StatusFailed, got.Value.Err, nil
+			}
+			return StatusConfirmed, nil, nil
+		case <-ticker.C:
+			if tick != nil {
+				tick()
+			}
+
+			if results == nil {
+				status, txErr, err := c.pollStatus(ctx, sig)
+				if err != nil {
+					return "", nil, err
+				}
+
+				if status != "" {
+					return status, txErr, nil
+				}
+			}
+
+			if expired == nil {
+				continue
+			}
+
+			ok, err := expired(ctx)
+			if err != nil {
+				return "", nil, err
+			}
+
+			if !ok {
+				continue
+			}
+
+			// transaction could land right before expiration
+			out, err := c.rpc.GetSignatureStatuses(ctx, true, sig)
+			if err != nil {
+				return "", nil, err
+			}
+
+			if len(out.Value) == 0 || out.Value[0] == nil {
+				return StatusExpired, nil, nil
+			}
+
+			if out.Value[0].Err != nil {
+				return StatusFailed, out.Value[0].Err, nil
+			}
+
+			// landed transaction can't expire, wait for its finalization
+			expired = nil
+		}
+	}
+}
+
+// Subscribe to signature status, nil channels are returned
+// if polling is configured or websocket is unavailable
+func (c *Client) subscribeSignature(ctx context.Context, sig solana.Signature) (<-chan *ws.SignatureResult, <-chan error, func()) {
+	if c.pollConfirmation {
+		return nil, nil, func() {}
+	}
+
+	conn, limiter, err := c.websocket(ctx)
+	if err != nil {
+		return nil, nil, func() {}
+	}
+
+	if err := limiter.Wait(ctx); err != nil {
+		return nil, nil, func() {}
+	}
+
+	sub, err := conn.SignatureSubscribe(sig, c.confirmCommitment)
+	if err != nil {
+		return nil, nil, func() {}
+	}
+
+	// Recv doesn't take context, so wait for it in background
+	results := make(chan *ws.SignatureResult, 1)
+	errs := make(chan error, 1)
+	go func() {
+		got, err := sub.Recv()
+		if err != nil {
+			errs <- err
+			return
+		}
+		results <- got
+	}()
+
+	return results, errs, sub.Unsubscribe
+}
+
+// Get final status of transaction from signature statuses, empty status if it isn't final yet
+func (c *Client) pollStatus(ctx context.Context, sig solana.Signature) (string, interface{}, error) {
+	out, err := c.rpc.GetSignatureStatuses(ctx, false, sig)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(out.Value) == 0 || out.Value[0] == nil {
+		return "", nil, nil
+	}
+
+	if out.Value[0].Err != nil {
+		return StatusFailed, out.Value[0].Err, nil
+	}
+
+	if !reachedCommitment(out.Value[0].ConfirmationStatus, c.confirmCommitment) {
+		return "", nil, nil
+	}
+
+	return StatusConfirmed, nil, nil
+}
+
+// Nonce transaction starts with advance nonce instruction and doesn't expire by block height
+func isNonceTransaction(tx *solana.Transaction) bool {
+	if len(tx.Message.Instructions) == 0 {
+		return false
+	}
+
+	instr := tx.Message.Instructions[0]
+	program, err := tx.ResolveProgramIDIndex(instr.ProgramIDIndex)
+	if err != nil || !program.Equals(solana.SystemProgramID) || len(instr.Data) < 4 {
+		return false
+	}
+
+	return instr.Data[0] == byte(system.Instruction_AdvanceNonceAccount) &&
+		instr.Data[1] == 0 && instr.Data[2] == 0 && instr.Data[3] == 0
+}
@@ -0,0 +1,278 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+	"github.com/gorilla/websocket"
+)
+
+// Slot lag after which endpoint is considered behind
+const maxSlotLag = 150
+
+// Health of RPC endpoint
+type EndpointHealth struct {
+	RPC     string `json:"rpc"`
+	Healthy bool   `json:"healthy"`
+	Slot    uint64 `json:"slot,omitempty"`
+	// Slots behind highest slot of endpoints
+	SlotLag uint64 `json:"slot_lag"`
+	Err     string `json:"error,omitempty"`
+}
+
+// JSON-RPC client over ordered endpoints, calls fail over
+// to next endpoint on transport errors and rate limits
+type failoverRPC struct {
+	endpoints []rpc.Cluster
+	clients   []*rpc.Client
+	// rate limiters of endpoints
+	limiters []*rateLimiter
+	retries  int
+
+	mu     sync.Mutex
+	active int
+	// endpoints failed on health check are tried last
+	unhealthy []bool
+}
+
+var _ rpc.JSONRPCClient = &failoverRPC{}
+
+func newFailoverRPC(endpoints []rpc.Cluster, opts *ClientOptions) *failoverRPC {
+	f := &failoverRPC{
+		endpoints: endpoints,
+		retries:   opts.MaxRetries,
+		unhealthy: make([]bool, len(endpoints)),
+	}
+
+	for _, endpoint := range endpoints {
+		limiter := newRateLimiter(opts.RateLimit, opts.RateBurst)
+		httpClient := &http.Client{
+			Transport: &limitedTransport{
+				base:    http.DefaultTransport,
+				limiter: limiter,
+				retries: opts.MaxRetries,
+			},
+		}
+
+		rpcClient := jsonrpc.NewClientWithOpts(endpoint.RPC, &jsonrpc.RPCClientOpts{HTTPClient: httpClient})
+		f.clients = append(f.clients, rpc.NewWithCustomRPCClient(rpcClient))
+		f.limiters = append(f.limiters, limiter)
+	}
+
+	return f
+}
+
+// Connect to websocket of endpoint, failed handshakes are retried with backoff
+func (f *failoverRPC) connectWebsocket(ctx context.Context, i int) (*ws.Client, error) {
+	for attempt := 0; ; attempt++ {
+		if err := f.limiters[i].Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		conn, err := ws.Connect(ctx, f.endpoints[i].WS)
+		if err == nil || !errors.Is(err, websocket.ErrBadHandshake) || attempt >= f.retries {
+			return conn, err
+		}
+
+		if err := sleep(ctx, backoffDelay(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (f *failoverRPC) activeIndex() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.active
+}
+
+func (f *failoverRPC) setActive(i int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.active = i
+}
+
+func (f *failoverRPC) setHealth(active int, unhealthy []bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.active = active
+	f.unhealthy = unhealthy
+}
+
+// Get endpoints in order of calls: active one, next healthy ones and unhealthy ones
+func (f *failoverRPC) order() []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	order := make([]int, 0, len(f.clients))
+	var last []int
+	for i := range f.clients {
+		idx := (f.active + i) % len(f.clients)
+		if i > 0 && f.unhealthy[idx] {
+			last = append(last, idx)
+			continue
+		}
+		order = append(order, idx)
+	}
+
+	return append(order, last...)
+}
+
+// Call endpoints in order, endpoint that answered becomes active
+func (f *failoverRPC) call(ctx context.Context, fn func(*rpc.Client) error) error {
+	order := f.order()
+
+	var err error
+	for i, idx := range order {
+		err = fn(f.clients[idx])
+		if !needsFailover(ctx, err) {
+			if i > 0 {
+				f.setActive(idx)
+			}
+			return err
+		}
+	}
+
+	return err
+}
+
+func (f *failoverRPC) CallForInto(ctx context.Context, out interface{}, method string, params []interface{}) error {
+	return f.call(ctx, func(c *rpc.Client) error {
+		return c.RPCCallForInto(ctx, out, method, params)
+	})
+}
+
+func (f *failoverRPC) CallWithCallback(ctx context.Context, method string, params []interface{}, callback func(*http.Request, *http.Response) error) error {
+	return f.call(ctx, func(c *rpc.Client) error {
+		return c.RPCCallWithCallback(ctx, method, params, callback)
+	})
+}
+
+// Errors of node are final, other errors are transport errors
+// which are worth to retry on next endpoint, as well as rate limits
+func needsFailover(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	var rpcErr *jsonrpc.RPCError
+	if errors.As(err, &rpcErr) {
+		return rpcErr.Code == http.StatusTooManyRequests
+	}
+
+	var httpErr *jsonrpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code == http.StatusTooManyRequests || httpErr.Code >= http.StatusInternalServerError
+	}
+
+	return true
+}
+
+// Check health and slot lag of endpoints, first healthy endpoint becomes active
+func (c *Client) CheckEndpoints(ctx context.Context) []EndpointHealth {
+	health := make([]EndpointHealth, len(c.endpoints.endpoints))
+
+	var wg sync.WaitGroup
+	for i := range c.endpoints.clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			health[i] = c.endpointHealth(ctx, i)
+		}(i)
+	}
+	wg.Wait()
+
+	var highest uint64
+	for _, h := range health {
+		if h.Slot > highest {
+			highest = h.Slot
+		}
+	}
+
+	active := -1
+	unhealthy := make([]bool, len(health))
+	for i := range health {
+		if health[i].Err == "" {
+			health[i].SlotLag = highest - health[i].Slot
+			health[i].Healthy = health[i].SlotLag <= maxSlotLag
+		}
+
+		unhealthy[i] = !health[i].Healthy
+		if health[i].Healthy && active < 0 {
+			active = i
+		}
+	}
+
+	// keep preferred endpoint if none is healthy
+	if active < 0 {
+		active = 0
+	}
+	c.endpoints.setHealth(active, unhealthy)
+
+	return health
+}
+
+func (c *Client) endpointHealth(ctx context.Context, i int) EndpointHealth {
+	health := EndpointHealth{RPC: c.endpoints.endpoints[i].RPC}
+	client := c.endpoints.clients[i]
+
+	status, err := client.GetHealth(ctx)
+	if err == nil && status != rpc.HealthOk {
+		err = errors.New(status)
+	}
+	if err != nil {
+		health.Err = err.Error()
+		return health
+	}
+
+	health.Slot, err = client.GetSlot(ctx, c.commitment)
+	if err != nil {
+		health.Err = err.Error()
+	}
+
+	return health
+}
+
+// Send transaction to all endpoints at once, result of active endpoint
+// is preferred and other endpoints give result if it needs failover
+func (c *Client) sendTransaction(ctx context.Context, tx *solana.Transaction, skipPreflight bool) (solana.Signature, error) {
+	clients := c.endpoints.clients
+	if len(clients) == 1 {
+		return c.rpc.SendTransactionWithOpts(ctx, tx, skipPreflight, c.commitment)
+	}
+
+	sigs := make([]solana.Signature, len(clients))
+	errs := make([]error, len(clients))
+
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sigs[i], errs[i] = clients[i].SendTransactionWithOpts(ctx, tx, skipPreflight, c.commitment)
+		}(i)
+	}
+	wg.Wait()
+
+	active := c.endpoints.activeIndex()
+	if !needsFailover(ctx, errs[active]) {
+		return sigs[active], errs[active]
+	}
+
+	for i, err := range errs {
+		if err == nil {
+			return sigs[i], nil
+		}
+	}
+
+	return solana.Signature{}, errs[active]
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Size of nonce account data
+const NonceAccountSize = 80
+
+// State of initialized nonce account
+const nonceInitialized = 1
+
+func (c *Client) RentExemption(ctx context.Context, size uint64) (uint64, error) {
+	return c.rpc.GetMinimumBalanceForRentExemption(ctx, size, c.commitment)
+}
+
+func (c *Client) NonceAccount(ctx context.Context, account solana.PublicKey) (*system.NonceAccount, error) {
+	out, err := c.rpc.GetAccountInfoWithOpts(ctx, account, &rpc.GetAccountInfoOpts{
+		Commitment: c.commitment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !out.Value.Owner.Equals(solana.SystemProgramID) {
+		return nil, fmt.Errorf("account %s is not a nonce account", account)
+	}
+
+	var nonce system.NonceAccount
+	if err := bin.NewBinDecoder(out.Value.Data.GetBinary()).Decode(&nonce); err != nil {
+		return nil, err
+	}
+
+	if nonce.State != nonceInitialized {
+		return nil, fmt.Errorf("nonce account %s is not initialized", account)
+	}
+
+	return &nonce, nil
+}
+
+// Create nonce account funded by owner, nonce account key must be in signers
+func (c *Client) CreateNonce(ctx context.Context, owner, nonceAccount, authority solana.PublicKey, lamports uint64, opts *SendOptions) (*SendResult, error) {
+	if lamports == 0 {
+		rent, err := c.RentExemption(ctx, NonceAccountSize)
+		if err != nil {
+			return nil, err
+		}
+		lamports = rent
+	}
+
+	create, err := system.NewCreateAccountInstruction(
+		lamports, NonceAccountSize, solana.SystemProgramID, owner, nonceAccount,
+	).ValidateAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	initialize, err := system.NewInitializeNonceAccountInstruction(
+		authority, nonceAccount, solana.SysVarRecentBlockHashesPubkey, solana.SysVarRentPubkey,
+	).ValidateAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SendInstructions(ctx, []solana.Instruction{create, initialize}, owner, opts)
+}
+
+// Advance nonce of account, owner is nonce authority
+func (c *Client) AdvanceNonce(ctx context.Context, owner, nonceAccount solana.PublicKey, opts *SendOptions) (*SendResult, error) {
+	advance, err := system.NewAdvanceNonceAccountInstruction(
+		nonceAccount, solana.SysVarRecentBlockHashesPubkey, owner,
+	).ValidateAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SendInstructions(ctx, []solana.Instruction{advance}, owner, opts)
+}
+
+// Withdraw lamports from nonce account, owner is nonce authority
+func (c *Client) WithdrawNonce(ctx context.Context, owner, nonceAccount, recipient solana.PublicKey, lamports uint64, opts *SendOptions) (*SendResult, error) {
+	withdraw, err := system.NewWithdrawNonceAccountInstruction(
+		lamports, nonceAccount, recipient, solana.SysVarRecentBlockHashesPubkey, solana.SysVarRentPubkey, owner,
+	).ValidateAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SendInstructions(ctx, []solana.Instruction{withdraw}, owner, opts)
+}
+
+// Set new nonce authority, owner is current nonce authority
+func (c *Client) AuthorizeNonce(ctx context.Context, owner, nonceAccount, authority solana.PublicKey, opts *SendOptions) (*SendResult, error) {
+	authorize, err := system.NewAuthorizeNonceAccountInstruction(
+		authority, nonceAccount, owner,
+	).ValidateAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SendInstructions(ctx, []solana.Instruction{authorize}, owner, opts)
+}
+
+// Get advance nonce instruction and stored nonce to use as blockhash
+func (c *Client) nonceBlockhash(ctx context.Context, nonceAccount, authority solana.PublicKey) (solana.Instruction, solana.Hash, error) {
+	nonce, err := c.NonceAccount(ctx, nonceAccount)
+	if err != nil {
+		return nil, solana.Hash{}, err
+	}
+
+	if !nonce.AuthorizedPubkey.Equals(authority) {
+		return nil, solana.Hash{}, fmt.Errorf("nonce authority of %s is %s", nonceAccount, nonce.AuthorizedPubkey)
+	}
+
+	advance, err := system.NewAdvanceNonceAccountInstruction(
+		nonceAccount, solana.SysVarRecentBlockHashesPubkey, authority,
+	).ValidateAndBuild()
+	if err != nil {
+		return nil, solana.Hash{}, err
+	}
+
+	return advance, solana.Hash(nonce.Nonce), nil
+}
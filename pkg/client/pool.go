@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"solana/pkg/model"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Offset of supply in spl token mint data
+const mintSupplyOffset = 36
+
+// Offset of unix timestamp in clock sysvar data
+const clockTimestampOffset = 32
+
+// Live balances of swap pool
+type PoolBalances struct {
+	ReserveA        uint64
+	ReserveB        uint64
+	PoolTokenSupply uint64
+}
+
+// Get reserve balances and pool token supply of swap in one batch,
+// clock sysvar is cached in the same batch for block time of quotes
+func (c *Client) PoolBalances(ctx context.Context, swapInfo *model.SwapInfo) (*PoolBalances, error) {
+	keys := []solana.PublicKey{swapInfo.TokenAReserve, swapInfo.TokenBReserve, swapInfo.PoolTokenMint}
+
+	if err := c.PrefetchAccounts(ctx, append(keys, solana.SysVarClockPubkey)...); err != nil {
+		return nil, err
+	}
+
+	accounts, err := c.Accounts(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, account := range accounts {
+		if account == nil {
+			return nil, fmt.Errorf("account %s: %w", keys[i], rpc.ErrNotFound)
+		}
+	}
+
+	return &PoolBalances{
+		ReserveA:        tokenAmount(accounts[0]),
+		ReserveB:        tokenAmount(accounts[1]),
+		PoolTokenSupply: mintSupply(accounts[2]),
+	}, nil
+}
+
+// Get reserves of token and its pair token
+func (b *PoolBalances) Reserves(swapInfo *model.SwapInfo, token solana.PublicKey) (uint64, uint64) {
+	if token == swapInfo.TokenBMint {
+		return b.ReserveB, b.ReserveA
+	}
+
+	return b.ReserveA, b.ReserveB
+}
+
+// Get cluster unix time from clock sysvar
+func (c *Client) BlockTime(ctx context.Context) (int64, error) {
+	account, err := c.Account(ctx, solana.SysVarClockPubkey)
+	if err != nil {
+		return 0, err
+	}
+
+	if account == nil || len(account.Data.GetBinary()) < clockTimestampOffset+8 {
+		return 0, errors.New("cann't read clock sysvar")
+	}
+
+	return int64(binary.LittleEndian.Uint64(account.Data.GetBinary()[clockTimestampOffset:])), nil
+}
+
+// Get supply from token mint, missing mint has zero supply
+func mintSupply(account *rpc.Account) uint64 {
+	if account == nil || account.Data == nil {
+		return 0
+	}
+
+	data := account.Data.GetBinary()
+	if len(data) < mintSupplyOffset+8 {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint64(data[mintSupplyOffset:])
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Max accounts accepted by getRecentPrioritizationFees
+const maxFeeAccounts = 128
+
+// Default percentile of recent priority fees for auto mode
+const DefaultFeePercentile = 75
+
+type prioritizationFee struct {
+	Slot              uint64 `json:"slot"`
+	PrioritizationFee uint64 `json:"prioritizationFee"`
+}
+
+// Get priority fee in micro-lamports per CU as percentile of recent fees paid for writable accounts
+func (c *Client) PriorityFee(ctx context.Context, accounts []solana.PublicKey, percentile int) (uint64, error) {
+	if len(accounts) > maxFeeAccounts {
+		accounts = accounts[:maxFeeAccounts]
+	}
+
+	var out []prioritizationFee
+	params := []interface{}{accounts}
+	if err := c.rpc.RPCCallForInto(ctx, &out, "getRecentPrioritizationFees", params); err != nil {
+		return 0, err
+	}
+
+	fees := make([]uint64, 0, len(out))
+	for _, fee := range out {
+		fees = append(fees, fee.PrioritizationFee)
+	}
+
+	return feePercentile(fees, percentile), nil
+}
+
+func feePercentile(fees []uint64, percentile int) uint64 {
+	if len(fees) == 0 {
+		return 0
+	}
+
+	sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+
+	if percentile <= 0 {
+		return fees[0]
+	}
+
+	idx := (len(fees)*percentile+99)/100 - 1
+	if idx >= len(fees) {
+		idx = len(fees) - 1
+	}
+
+	return fees[idx]
+}
+
+// Get unique writable accounts of instructions
+func writableAccounts(instrs []solana.Instruction) []solana.PublicKey {
+	seen := map[solana.PublicKey]bool{}
+	out := []solana.PublicKey{}
+	for _, instr := range instrs {
+		for _, account := range instr.Accounts() {
+			if account.IsWritable && !seen[account.PublicKey] {
+				seen[account.PublicKey] = true
+				out = append(out, account.PublicKey)
+			}
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Bounds of exponential backoff between retries
+const (
+	minBackoff = 500 * time.Millisecond
+	maxBackoff = 30 * time.Second
+)
+
+// Token bucket limiter of requests
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// Get limiter of requests per second with burst, zero rate doesn't limit
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait for token, nil limiter doesn't wait
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	// token is reserved, so waiters are served in order
+	l.tokens--
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	return sleep(ctx, wait)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Get delay before retry: exponential backoff with jitter
+func backoffDelay(attempt int) time.Duration {
+	delay := maxBackoff
+	if attempt < 16 {
+		delay = minBackoff << attempt
+	}
+
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// Get delay from Retry-After header in seconds or http date, zero if it isn't set
+func retryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(header); err == nil {
+		return time.Until(at)
+	}
+
+	return 0
+}
+
+// Rate limited and server failed requests are worth to retry
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// HTTP transport limiting rate of requests and retrying
+// rate limited and server failed requests with backoff
+type limitedTransport struct {
+	base    http.RoundTripper
+	limiter *rateLimiter
+	retries int
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || !retryableStatus(resp.StatusCode) || attempt >= t.retries {
+			return resp, err
+		}
+
+		// body is consumed by sent request, so it must be recreated
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		// too long wait is left to caller, e.g. to fail over
+		delay := retryAfter(resp.Header.Get("Retry-After"))
+		if delay > maxBackoff {
+			return resp, nil
+		}
+		if delay <= 0 {
+			delay = backoffDelay(attempt)
+		}
+		resp.Body.Close()
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+
+		next := req.Clone(ctx)
+		if req.GetBody != nil {
+			next.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+		req = next
+	}
+}
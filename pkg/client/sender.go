@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Default interval of transaction rebroadcast
+const DefaultRebroadcastInterval = 2 * time.Second
+
+// Sender rebroadcasts signed transaction until it is confirmed, failed or expired
+type Sender struct {
+	client *Client
+	// Interval of rebroadcast and expiration checks
+	Interval time.Duration
+	// Max sends of transaction in total, zero sends until expiration
+	MaxAttempts int
+	// Max re-signs of expired transaction with new blockhash
+	Resigns int
+	// Keys to re-sign transaction with
+	Signers []solana.PrivateKey
+}
+
+// Get sender configured by send options
+func (c *Client) NewSender(opts *SendOptions) *Sender {
+	sender := &Sender{client: c, Interval: DefaultRebroadcastInterval}
+	if opts == nil {
+		return sender
+	}
+
+	if opts.RebroadcastInterval > 0 {
+		sender.Interval = opts.RebroadcastInterval
+	}
+	sender.MaxAttempts = opts.MaxAttempts
+	sender.Resigns = opts.Resigns
+	sender.Signers = opts.Signers
+
+	return sender
+}
+
+// Send transaction and rebroadcast it until final status.
+// Failed and expired transactions are reported by status, not error.
+// Zero last valid block height checks expiration by transaction blockhash.
+func (s *Sender) Send(ctx context.Context, tx *solana.Transaction, lastValidBlockHeight uint64) (*SendResult, error) {
+	res := &SendResult{}
+
+	for resign := 0; ; resign++ {
+		sig, err := s.client.sendTransaction(ctx, tx, false)
+		if err != nil {
+			return nil, err
+		}
+		res.Attempts++
+
+		// rebroadcast errors are expected, e.g. for already processed transaction
+		rebroadcast := func() {
+			if !s.canSend(res) {
+				return
+			}
+
+			if _, err := s.client.sendTransaction(ctx, tx, true); err == nil {
+				res.Attempts++
+			}
+		}
+
+		status, txErr, err := s.client.waitStatus(ctx, sig, s.client.txExpiry(tx, lastValidBlockHeight), s.Interval, rebroadcast)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Signature = sig
+		res.LastValidBlockHeight = lastValidBlockHeight
+		res.Status = status
+		res.Err = txErr
+
+		if status != StatusExpired || resign >= s.Resigns || len(s.Signers) == 0 || !s.canSend(res) {
+			return res, nil
+		}
+
+		lastValidBlockHeight, err = s.resign(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s *Sender) canSend(res *SendResult) bool {
+	return s.MaxAttempts == 0 || res.Attempts < s.MaxAttempts
+}
+
+// Sign transaction with new blockhash
+func (s *Sender) resign(ctx context.Context, tx *solana.Transaction) (uint64, error) {
+	recent, lastValidBlockHeight, err := s.client.Recent(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx.Message.RecentBlockhash = recent
+	tx.Signatures = nil
+
+	if err := PartialSign(tx, s.Signers); err != nil {
+		return 0, err
+	}
+
+	if missing := MissingSigners(tx); len(missing) > 0 {
+		return 0, fmt.Errorf("cann't re-sign transaction without key of %s", missing[0])
+	}
+
+	return lastValidBlockHeight, nil
+}
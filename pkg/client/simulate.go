@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Offset of amount in spl token account data
+const tokenAmountOffset = 64
+
+// Result of transaction simulation
+type Simulation struct {
+	Err           interface{}     `json:"err,omitempty"`
+	Logs          []string        `json:"logs"`
+	UnitsConsumed uint64          `json:"units_consumed"`
+	Balances      []BalanceChange `json:"balances,omitempty"`
+}
+
+// Token account balance before and after transaction
+type BalanceChange struct {
+	Account solana.PublicKey `json:"account"`
+	Pre     uint64           `json:"pre"`
+	Post    uint64           `json:"post"`
+}
+
+// Change of balance, negative when tokens are spent
+func (b BalanceChange) Delta() int64 {
+	return int64(b.Post - b.Pre)
+}
+
+// Simulate signed transaction and get balances of watch token accounts
+func (c *Client) Simulate(ctx context.Context, tx *solana.Transaction, watch []solana.PublicKey) (*Simulation, error) {
+	pre := make([]uint64, len(watch))
+	if len(watch) > 0 {
+		out, err := c.rpc.GetMultipleAccountsWithOpts(ctx, watch, &rpc.GetMultipleAccountsOpts{
+			Encoding:   solana.EncodingBase64,
+			Commitment: c.commitment,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i, account := range out.Value {
+			pre[i] = tokenAmount(account)
+		}
+	}
+
+	txData, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := rpc.M{
+		"encoding":   solana.EncodingBase64,
+		"sigVerify":  true,
+		"commitment": c.commitment,
+	}
+	if len(watch) > 0 {
+		opts["accounts"] = rpc.M{
+			"encoding":  solana.EncodingBase64,
+			"addresses": watch,
+		}
+	}
+
+	// rpc.SimulateTransaction of solana-go doesn't unwrap response value
+	var out struct {
+		Value rpc.SimulateTransactionResponse `json:"value"`
+	}
+	params := []interface{}{base64.StdEncoding.EncodeToString(txData), opts}
+	if err := c.rpc.RPCCallForInto(ctx, &out, "simulateTransaction", params); err != nil {
+		return nil, err
+	}
+
+	sim := &Simulation{
+		Err:  out.Value.Err,
+		Logs: out.Value.Logs,
+	}
+
+	if out.Value.UnitsConsumed != nil {
+		sim.UnitsConsumed = *out.Value.UnitsConsumed
+	}
+
+	// accounts are not returned when transaction fails
+	if len(out.Value.Accounts) == len(watch) {
+		for i, account := range watch {
+			sim.Balances = append(sim.Balances, BalanceChange{
+				Account: account,
+				Pre:     pre[i],
+				Post:    tokenAmount(out.Value.Accounts[i]),
+			})
+		}
+	}
+
+	return sim, nil
+}
+
+// Get amount from token account, missing account has zero amount
+func tokenAmount(account *rpc.Account) uint64 {
+	if account == nil || account.Data == nil {
+		return 0
+	}
+
+	data := account.Data.GetBinary()
+	if len(data) < tokenAmountOffset+8 {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint64(data[tokenAmountOffset:])
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/mr-tron/base58"
+)
+
+// Serialized transaction encodings
+const (
+	EncodingBase64 = "base64"
+	EncodingBase58 = "base58"
+)
+
+// Serialize transaction, missing signatures are encoded as zero bytes
+func EncodeTransaction(tx *solana.Transaction, encoding string) (string, error) {
+	if len(tx.Signatures) == 0 {
+		tx.Signatures = make([]solana.Signature, tx.Message.Header.NumRequiredSignatures)
+	}
+
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+
+	switch encoding {
+	case EncodingBase64:
+		return base64.StdEncoding.EncodeToString(data), nil
+	case EncodingBase58:
+		return base58.Encode(data), nil
+	default:
+		return "", fmt.Errorf("unknown transaction encoding %s", encoding)
+	}
+}
+
+// Deserialize transaction
+func DecodeTransaction(content, encoding string) (*solana.Transaction, error) {
+	var data []byte
+	var err error
+
+	switch encoding {
+	case EncodingBase64:
+		data, err = base64.StdEncoding.DecodeString(content)
+	case EncodingBase58:
+		data, err = base58.Decode(content)
+	default:
+		return nil, fmt.Errorf("unknown transaction encoding %s", encoding)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode transaction: %w", err)
+	}
+
+	tx, err := solana.TransactionFromDecoder(bin.NewBinDecoder(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode transaction: %w", err)
+	}
+
+	return tx, nil
+}
+
+// Sign transaction with keys of required signers, other signatures are kept
+func PartialSign(tx *solana.Transaction, keys []solana.PrivateKey) error {
+	message, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	signers := tx.Message.Signers()
+	if len(tx.Signatures) != len(signers) {
+		signatures := make([]solana.Signature, len(signers))
+		copy(signatures, tx.Signatures)
+		tx.Signatures = signatures
+	}
+
+	for i, signer := range signers {
+		for _, key := range keys {
+			if !key.PublicKey().Equals(signer) {
+				continue
+			}
+
+			sig, err := key.Sign(message)
+			if err != nil {
+				return err
+			}
+
+			tx.Signatures[i] = sig
+		}
+	}
+
+	return nil
+}
+
+// Get required signers without signature
+func MissingSigners(tx *solana.Transaction) []solana.PublicKey {
+	missing := []solana.PublicKey{}
+	for i, signer := range tx.Message.Signers() {
+		if i >= len(tx.Signatures) || tx.Signatures[i].IsZero() {
+			missing = append(missing, signer)
+		}
+	}
+
+	return missing
+}
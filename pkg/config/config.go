@@ -0,0 +1,191 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Name of profile in new config
+const DefaultProfile = "default"
+
+// Profile keys
+var Keys = []string{"cluster", "rpc_url", "ws_url", "backup_rpc_url", "keypair", "wallet", "commitment", "poll_confirmation", "rate_limit", "program", "registry"}
+
+// CLI config with named profiles
+type Config struct {
+	Current  string              `yaml:"current" json:"current"`
+	Profiles map[string]*Profile `yaml:"profiles" json:"profiles"`
+}
+
+// Profile settings
+type Profile struct {
+	Cluster          string `yaml:"cluster,omitempty" json:"cluster,omitempty"`
+	RpcUrl           string `yaml:"rpc_url,omitempty" json:"rpc_url,omitempty"`
+	WsUrl            string `yaml:"ws_url,omitempty" json:"ws_url,omitempty"`
+	BackupRpcUrl     string `yaml:"backup_rpc_url,omitempty" json:"backup_rpc_url,omitempty"`
+	Keypair          string `yaml:"keypair,omitempty" json:"keypair,omitempty"`
+	Wallet           string `yaml:"wallet,omitempty" json:"wallet,omitempty"`
+	Commitment       string `yaml:"commitment,omitempty" json:"commitment,omitempty"`
+	PollConfirmation string `yaml:"poll_confirmation,omitempty" json:"poll_confirmation,omitempty"`
+	RateLimit        string `yaml:"rate_limit,omitempty" json:"rate_limit,omitempty"`
+	Program          string `yaml:"program,omitempty" json:"program,omitempty"`
+	Registry         string `yaml:"registry,omitempty" json:"registry,omitempty"`
+}
+
+// Get default config path
+func DefaultPath() (string, error) {
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(config, "solana_cli", "config.yaml"), nil
+}
+
+// Get new config with empty default profile
+func New() *Config {
+	return &Config{
+		Current:  DefaultProfile,
+		Profiles: map[string]*Profile{DefaultProfile: {}},
+	}
+}
+
+// Load config from path, missing file gives new config
+func Load(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	config := New()
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("decode config %s: %w", path, err)
+	}
+
+	if config.Profiles == nil {
+		config.Profiles = map[string]*Profile{}
+	}
+
+	return config, nil
+}
+
+// Save config to path
+func (c *Config) Save(path string) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0600)
+}
+
+// Get profile by name, empty name gives current profile
+func (c *Config) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = c.Current
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok || profile == nil {
+		return nil, fmt.Errorf("profile %s not found", name)
+	}
+
+	return profile, nil
+}
+
+// Get profile by name or add new one
+func (c *Config) ProfileOrNew(name string) *Profile {
+	if name == "" {
+		name = c.Current
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok || profile == nil {
+		profile = &Profile{}
+		c.Profiles[name] = profile
+	}
+
+	return profile
+}
+
+// Switch current profile
+func (c *Config) Use(name string) error {
+	if _, err := c.Profile(name); err != nil {
+		return err
+	}
+
+	c.Current = name
+	return nil
+}
+
+// Get sorted profile names
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Get profile value by key
+func (p *Profile) Get(key string) (string, error) {
+	field, err := p.field(key)
+	if err != nil {
+		return "", err
+	}
+
+	return *field, nil
+}
+
+// Set profile value by key
+func (p *Profile) Set(key, value string) error {
+	field, err := p.field(key)
+	if err != nil {
+		return err
+	}
+
+	*field = value
+	return nil
+}
+
+func (p *Profile) field(key string) (*string, error) {
+	switch key {
+	case "cluster":
+		return &p.Cluster, nil
+	case "rpc_url":
+		return &p.RpcUrl, nil
+	case "ws_url":
+		return &p.WsUrl, nil
+	case "backup_rpc_url":
+		return &p.BackupRpcUrl, nil
+	case "keypair":
+		return &p.Keypair, nil
+	case "wallet":
+		return &p.Wallet, nil
+	case "commitment":
+		return &p.Commitment, nil
+	case "poll_confirmation":
+		return &p.PollConfirmation, nil
+	case "rate_limit":
+		return &p.RateLimit, nil
+	case "program":
+		return &p.Program, nil
+	case "registry":
+		return &p.Registry, nil
+	default:
+		return nil, fmt.Errorf("unknown config key %s", key)
+	}
+}
@@ -0,0 +1,117 @@
+package hdwallet
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Hardened child index offset
+const hardenedOffset = 0x80000000
+
+// SLIP-0010 ed25519 master key HMAC key
+const ed25519Curve = "ed25519 seed"
+
+// Default derivation path used by Phantom and Solflare
+const DefaultPath = "m/44'/501'/0'/0'"
+
+// Get derivation path of account n
+func AccountPath(n uint32) string {
+	return fmt.Sprintf("m/44'/501'/%d'/0'", n)
+}
+
+// Generate new BIP39 mnemonic with 12 or 24 words
+func NewMnemonic(words int) (string, error) {
+	var bits int
+	switch words {
+	case 12:
+		bits = 128
+	case 24:
+		bits = 256
+	default:
+		return "", fmt.Errorf("mnemonic must have 12 or 24 words, got %d", words)
+	}
+
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// Get seed from mnemonic and optional BIP39 passphrase
+func SeedFromMnemonic(mnemonic, passphrase string) ([]byte, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	return bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+}
+
+// Parse derivation path to hardened child indexes
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("derivation path %q must start with m", path)
+	}
+
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		if !strings.HasSuffix(part, "'") {
+			return nil, fmt.Errorf("derivation path %q: ed25519 supports only hardened indexes", path)
+		}
+
+		index, err := strconv.ParseUint(strings.TrimSuffix(part, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("derivation path %q: %w", path, err)
+		}
+
+		indexes = append(indexes, uint32(index)+hardenedOffset)
+	}
+
+	return indexes, nil
+}
+
+// Derive private key from seed by SLIP-0010 ed25519 derivation path
+func Derive(seed []byte, path string) (solana.PrivateKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, chainCode := split(hmacSHA512([]byte(ed25519Curve), seed))
+	for _, index := range indexes {
+		data := make([]byte, 37)
+		copy(data[1:33], key)
+		binary.BigEndian.PutUint32(data[33:], index)
+
+		key, chainCode = split(hmacSHA512(chainCode, data))
+	}
+
+	return solana.PrivateKey(ed25519.NewKeyFromSeed(key)), nil
+}
+
+// Derive private key from mnemonic by derivation path
+func FromMnemonic(mnemonic, passphrase, path string) (solana.PrivateKey, error) {
+	seed, err := SeedFromMnemonic(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return Derive(seed, path)
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func split(sum []byte) ([]byte, []byte) {
+	return sum[:32], sum[32:]
+}
@@ -0,0 +1,56 @@
+package instructions
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Instruction account with its role name
+type NamedAccount struct {
+	Name      string           `json:"name"`
+	PublicKey solana.PublicKey `json:"public_key"`
+	Writable  bool             `json:"writable"`
+	Signer    bool             `json:"signer"`
+}
+
+// Log accounts
+func ShowAccounts(accounts []NamedAccount) {
+	for _, account := range accounts {
+		log.Println(account.Name+":\t", account.PublicKey.String())
+	}
+}
+
+// Get accounts of instructions named by instruction and account index
+func InstructionAccounts(instrs []solana.Instruction) []NamedAccount {
+	out := []NamedAccount{}
+	for i, instr := range instrs {
+		for j, account := range instr.Accounts() {
+			out = append(out, NamedAccount{
+				Name:      fmt.Sprintf("Instruction %d account %d", i, j),
+				PublicKey: account.PublicKey,
+				Writable:  account.IsWritable,
+				Signer:    account.IsSigner,
+			})
+		}
+	}
+	return out
+}
+
+func namedAccounts(names []string, accounts []*solana.AccountMeta) []NamedAccount {
+	out := make([]NamedAccount, 0, len(names))
+	for i, name := range names {
+		if i >= len(accounts) || accounts[i] == nil {
+			continue
+		}
+
+		out = append(out, NamedAccount{
+			Name:      name,
+			PublicKey: accounts[i].PublicKey,
+			Writable:  accounts[i].IsWritable,
+			Signer:    accounts[i].IsSigner,
+		})
+	}
+	return out
+}
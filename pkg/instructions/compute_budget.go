@@ -0,0 +1,66 @@
+package instructions
+
+import (
+	"bytes"
+
+	ag_binary "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// Compute budget program account
+var ComputeBudgetProgramID = solana.MustPublicKeyFromBase58("ComputeBudget111111111111111111111111111111")
+
+/// Set a specific compute unit limit that the transaction is allowed to consume.
+///
+/// No accounts.
+
+// Compute unit limit instruction data
+type ComputeUnitLimitData struct {
+	Prog  uint8
+	Units uint32
+}
+
+// Get compute unit limit data bytes
+func (c *ComputeUnitLimitData) GetBytes() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	err := ag_binary.NewBinEncoder(buf).Encode(c)
+	return buf.Bytes(), err
+}
+
+// Get new compute unit limit instruction
+func NewComputeUnitLimit(units uint32) (*solana.GenericInstruction, error) {
+	data, err := (&ComputeUnitLimitData{Prog: 2, Units: units}).GetBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return solana.NewInstruction(ComputeBudgetProgramID, solana.AccountMetaSlice{}, data), nil
+}
+
+/// Set a compute unit price in micro-lamports to pay a higher transaction
+/// fee for higher transaction prioritization.
+///
+/// No accounts.
+
+// Compute unit price instruction data
+type ComputeUnitPriceData struct {
+	Prog          uint8
+	MicroLamports uint64
+}
+
+// Get compute unit price data bytes
+func (c *ComputeUnitPriceData) GetBytes() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	err := ag_binary.NewBinEncoder(buf).Encode(c)
+	return buf.Bytes(), err
+}
+
+// Get new compute unit price instruction
+func NewComputeUnitPrice(microLamports uint64) (*solana.GenericInstruction, error) {
+	data, err := (&ComputeUnitPriceData{Prog: 3, MicroLamports: microLamports}).GetBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return solana.NewInstruction(ComputeBudgetProgramID, solana.AccountMetaSlice{}, data), nil
+}
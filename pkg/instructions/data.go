@@ -29,6 +29,31 @@ func (s *SwapData) GetBytes() ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+// Deposit instruction data
+type DepositData struct {
+	Prog              uint8
+	TokenAmountA      uint64
+	TokenAmountB      uint64
+	MinimumMintAmount uint64
+}
+
+// Get new deposit data
+func NewDepositData(amountA, amountB, minMint uint64) *DepositData {
+	return &DepositData{
+		Prog:              2,
+		TokenAmountA:      amountA,
+		TokenAmountB:      amountB,
+		MinimumMintAmount: minMint,
+	}
+}
+
+// Get deposit data bytes
+func (d *DepositData) GetBytes() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	err := ag_binary.NewBinEncoder(buf).Encode(d)
+	return buf.Bytes(), err
+}
+
 // Withdraw instruction data
 type WithdrawData struct {
 	Prog                uint8
@@ -0,0 +1,108 @@
+package instructions
+
+import (
+	"errors"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+/// Deposit some tokens into the pool.  The output is a "pool" token representing ownership
+/// into the pool. Inputs are converted to the current ratio.
+///
+/// 0. `[]`StableSwap
+/// 1. `[]` $authority
+/// 2. `[signer]` User authority.
+/// 3. `[writable]` token_a $authority can transfer amount,
+/// 4. `[writable]` token_b $authority can transfer amount,
+/// 5. `[writable]` token_a Base Account to deposit into.
+/// 6. `[writable]` token_b Base Account to deposit into.
+/// 7. `[writable]` Pool MINT account, $authority is the owner.
+/// 8. `[writable]` Pool Account to deposit the generated tokens, user is the owner.
+/// 9. `[]` Token program id
+
+type Deposit struct {
+	prog     solana.PublicKey
+	accounts []*solana.AccountMeta
+	data     []byte
+}
+
+// Account names in instruction order
+var depositAccountNames = []string{
+	"Swap account",
+	"Authority",
+	"User Authority",
+	"User Source A",
+	"User Source B",
+	"Pool Destination A",
+	"Pool Destination B",
+	"Pool Mint",
+	"User Pool Destination",
+}
+
+func NewDeposit(prog solana.PublicKey) *Deposit {
+	return &Deposit{prog: prog, accounts: make([]*solana.AccountMeta, 9)}
+}
+
+func (i *Deposit) Build() (*solana.GenericInstruction, error) {
+	if len(i.data) == 0 {
+		return nil, errors.New("add data bytes to instruction")
+	}
+
+	i.accounts = append(i.accounts, solana.NewAccountMeta(solana.TokenProgramID, false, false))
+
+	return solana.NewInstruction(i.prog, i.accounts, i.data), nil
+}
+
+func (i *Deposit) SetData(data []byte) *Deposit {
+	i.data = data
+	return i
+}
+
+func (i *Deposit) SetSwapAccount(key solana.PublicKey) *Deposit {
+	i.accounts[0] = solana.NewAccountMeta(key, false, false)
+	return i
+}
+
+func (i *Deposit) SetAuthority(key solana.PublicKey) *Deposit {
+	i.accounts[1] = solana.NewAccountMeta(key, false, false)
+	return i
+}
+
+func (i *Deposit) SetUserAuthority(key solana.PublicKey) *Deposit {
+	i.accounts[2] = solana.NewAccountMeta(key, true, true)
+	return i
+}
+
+func (i *Deposit) SetUserSourceA(key solana.PublicKey) *Deposit {
+	i.accounts[3] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Deposit) SetUserSourceB(key solana.PublicKey) *Deposit {
+	i.accounts[4] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Deposit) SetPoolDestinationA(key solana.PublicKey) *Deposit {
+	i.accounts[5] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Deposit) SetPoolDestinationB(key solana.PublicKey) *Deposit {
+	i.accounts[6] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Deposit) SetPoolMint(key solana.PublicKey) *Deposit {
+	i.accounts[7] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Deposit) SetUserPoolDestination(key solana.PublicKey) *Deposit {
+	i.accounts[8] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Deposit) Accounts() []NamedAccount {
+	return namedAccounts(depositAccountNames, i.accounts)
+}
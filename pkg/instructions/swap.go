@@ -2,7 +2,6 @@ package instructions
 
 import (
 	"errors"
-	"log"
 
 	"github.com/gagliardetto/solana-go"
 )
@@ -25,6 +24,18 @@ type Swap struct {
 	data     []byte
 }
 
+// Account names in instruction order
+var swapAccountNames = []string{
+	"Swap account",
+	"Authority",
+	"User Authority",
+	"User Source",
+	"Pool Source",
+	"Pool Destination",
+	"User Destination",
+	"Admin Destination",
+}
+
 func NewSwap(prog solana.PublicKey) *Swap {
 	return &Swap{prog: prog, accounts: make([]*solana.AccountMeta, 8)}
 }
@@ -84,13 +95,6 @@ func (i *Swap) SetAdminDestination(key solana.PublicKey) *Swap {
 	return i
 }
 
-func (i *Swap) ShowAccounts() {
-	log.Println("Swap account:\t", i.accounts[0].PublicKey.String())
-	log.Println("Authority:\t", i.accounts[1].PublicKey.String())
-	log.Println("User Authority:\t", i.accounts[2].PublicKey.String())
-	log.Println("User Source:\t", i.accounts[3].PublicKey.String())
-	log.Println("Pool Source:\t", i.accounts[4].PublicKey.String())
-	log.Println("Pool Destination:\t", i.accounts[5].PublicKey.String())
-	log.Println("User Destination:\t", i.accounts[6].PublicKey.String())
-	log.Println("Admin Destination:\t", i.accounts[7].PublicKey.String())
+func (i *Swap) Accounts() []NamedAccount {
+	return namedAccounts(swapAccountNames, i.accounts)
 }
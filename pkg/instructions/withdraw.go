@@ -0,0 +1,121 @@
+package instructions
+
+import (
+	"errors"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+/// Withdraw the token from the pool at the current ratio.
+///
+/// 0. `[]`StableSwap
+/// 1. `[]` $authority
+/// 2. `[signer]` User authority.
+/// 3. `[writable]` Pool mint account, $authority is the owner
+/// 4. `[writable]` SOURCE Pool account, amount is transferable by $authority.
+/// 5. `[writable]` token_a Swap Account to withdraw FROM.
+/// 6. `[writable]` token_b Swap Account to withdraw FROM.
+/// 7. `[writable]` token_a user Account to credit.
+/// 8. `[writable]` token_b user Account to credit.
+/// 9. `[writable]` admin_fee_a admin fee Account for token_a.
+/// 10. `[writable]` admin_fee_b admin fee Account for token_b.
+/// 11. `[]` Token program id
+
+type Withdraw struct {
+	prog     solana.PublicKey
+	accounts []*solana.AccountMeta
+	data     []byte
+}
+
+// Account names in instruction order
+var withdrawAccountNames = []string{
+	"Swap account",
+	"Authority",
+	"User Authority",
+	"Pool Mint",
+	"User Pool Source",
+	"Pool Source A",
+	"Pool Source B",
+	"User Destination A",
+	"User Destination B",
+	"Admin Destination A",
+	"Admin Destination B",
+}
+
+func NewWithdraw(prog solana.PublicKey) *Withdraw {
+	return &Withdraw{prog: prog, accounts: make([]*solana.AccountMeta, 11)}
+}
+
+func (i *Withdraw) Build() (*solana.GenericInstruction, error) {
+	if len(i.data) == 0 {
+		return nil, errors.New("add data bytes to instruction")
+	}
+
+	i.accounts = append(i.accounts, solana.NewAccountMeta(solana.TokenProgramID, false, false))
+
+	return solana.NewInstruction(i.prog, i.accounts, i.data), nil
+}
+
+func (i *Withdraw) SetData(data []byte) *Withdraw {
+	i.data = data
+	return i
+}
+
+func (i *Withdraw) SetSwapAccount(key solana.PublicKey) *Withdraw {
+	i.accounts[0] = solana.NewAccountMeta(key, false, false)
+	return i
+}
+
+func (i *Withdraw) SetAuthority(key solana.PublicKey) *Withdraw {
+	i.accounts[1] = solana.NewAccountMeta(key, false, false)
+	return i
+}
+
+func (i *Withdraw) SetUserAuthority(key solana.PublicKey) *Withdraw {
+	i.accounts[2] = solana.NewAccountMeta(key, true, true)
+	return i
+}
+
+func (i *Withdraw) SetPoolMint(key solana.PublicKey) *Withdraw {
+	i.accounts[3] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Withdraw) SetUserPoolSource(key solana.PublicKey) *Withdraw {
+	i.accounts[4] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Withdraw) SetPoolSourceA(key solana.PublicKey) *Withdraw {
+	i.accounts[5] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Withdraw) SetPoolSourceB(key solana.PublicKey) *Withdraw {
+	i.accounts[6] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Withdraw) SetUserDestinationA(key solana.PublicKey) *Withdraw {
+	i.accounts[7] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Withdraw) SetUserDestinationB(key solana.PublicKey) *Withdraw {
+	i.accounts[8] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Withdraw) SetAdminDestinationA(key solana.PublicKey) *Withdraw {
+	i.accounts[9] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Withdraw) SetAdminDestinationB(key solana.PublicKey) *Withdraw {
+	i.accounts[10] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *Withdraw) Accounts() []NamedAccount {
+	return namedAccounts(withdrawAccountNames, i.accounts)
+}
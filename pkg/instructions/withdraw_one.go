@@ -0,0 +1,107 @@
+package instructions
+
+import (
+	"errors"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+/// Withdraw one token from the pool at the current ratio.
+///
+/// 0. `[]`StableSwap
+/// 1. `[]` $authority
+/// 2. `[signer]` User authority.
+/// 3. `[writable]` Pool mint account, $authority is the owner
+/// 4. `[writable]` SOURCE Pool account, amount is transferable by $authority.
+/// 5. `[writable]` token_(A|B) BASE token Swap Account to withdraw FROM.
+/// 6. `[writable]` token_(A|B) QUOTE token Swap Account to exchange to base token.
+/// 7. `[writable]` token_(A|B) BASE token user Account to credit.
+/// 8. `[writable]` token_(A|B) admin fee Account. Must have same mint as BASE token.
+/// 9. `[]` Token program id
+
+type WithdrawOne struct {
+	prog     solana.PublicKey
+	accounts []*solana.AccountMeta
+	data     []byte
+}
+
+// Account names in instruction order
+var withdrawOneAccountNames = []string{
+	"Swap account",
+	"Authority",
+	"User Authority",
+	"Pool Mint",
+	"User Pool Source",
+	"Pool Base Source",
+	"Pool Quote Source",
+	"User Destination",
+	"Admin Destination",
+}
+
+func NewWithdrawOne(prog solana.PublicKey) *WithdrawOne {
+	return &WithdrawOne{prog: prog, accounts: make([]*solana.AccountMeta, 9)}
+}
+
+func (i *WithdrawOne) Build() (*solana.GenericInstruction, error) {
+	if len(i.data) == 0 {
+		return nil, errors.New("add data bytes to instruction")
+	}
+
+	i.accounts = append(i.accounts, solana.NewAccountMeta(solana.TokenProgramID, false, false))
+
+	return solana.NewInstruction(i.prog, i.accounts, i.data), nil
+}
+
+func (i *WithdrawOne) SetData(data []byte) *WithdrawOne {
+	i.data = data
+	return i
+}
+
+func (i *WithdrawOne) SetSwapAccount(key solana.PublicKey) *WithdrawOne {
+	i.accounts[0] = solana.NewAccountMeta(key, false, false)
+	return i
+}
+
+func (i *WithdrawOne) SetAuthority(key solana.PublicKey) *WithdrawOne {
+	i.accounts[1] = solana.NewAccountMeta(key, false, false)
+	return i
+}
+
+func (i *WithdrawOne) SetUserAuthority(key solana.PublicKey) *WithdrawOne {
+	i.accounts[2] = solana.NewAccountMeta(key, true, true)
+	return i
+}
+
+func (i *WithdrawOne) SetPoolMint(key solana.PublicKey) *WithdrawOne {
+	i.accounts[3] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *WithdrawOne) SetUserPoolSource(key solana.PublicKey) *WithdrawOne {
+	i.accounts[4] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *WithdrawOne) SetPoolBaseSource(key solana.PublicKey) *WithdrawOne {
+	i.accounts[5] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *WithdrawOne) SetPoolQuoteSource(key solana.PublicKey) *WithdrawOne {
+	i.accounts[6] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *WithdrawOne) SetUserDestination(key solana.PublicKey) *WithdrawOne {
+	i.accounts[7] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *WithdrawOne) SetAdminDestination(key solana.PublicKey) *WithdrawOne {
+	i.accounts[8] = solana.NewAccountMeta(key, true, false)
+	return i
+}
+
+func (i *WithdrawOne) Accounts() []NamedAccount {
+	return namedAccounts(withdrawOneAccountNames, i.accounts)
+}
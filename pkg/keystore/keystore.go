@@ -0,0 +1,248 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Scrypt parameters
+const (
+	scryptN      = 1 << 18
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 32
+)
+
+// Wallet file extension
+const fileExt = ".json"
+
+var (
+	ErrNotFound      = errors.New("wallet not found")
+	ErrExists        = errors.New("wallet already exists")
+	ErrBadPassphrase = errors.New("wrong passphrase")
+
+	namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// Encrypted wallet in keystore
+type Entry struct {
+	Name      string           `json:"name"`
+	PublicKey solana.PublicKey `json:"public_key"`
+	Crypto    Crypto           `json:"crypto"`
+}
+
+// Encryption params of wallet
+type Crypto struct {
+	Cipher     string `json:"cipher"`
+	KDF        string `json:"kdf"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Passphrase encrypted wallets directory
+type Keystore struct {
+	dir string
+	// scrypt cost of new wallets
+	n int
+}
+
+// Get default keystore directory
+func DefaultDir() (string, error) {
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(config, "solana_cli", "keystore"), nil
+}
+
+// Get new keystore in directory
+func New(dir string) *Keystore {
+	return &Keystore{dir: dir, n: scryptN}
+}
+
+// Encrypt private key with passphrase and store it by name
+func (k *Keystore) Create(name string, key solana.PrivateKey, passphrase []byte) (*Entry, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	path := k.path(name)
+	if _, err := os.Stat(path); err == nil {
+		return nil, ErrExists
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(passphrase, salt, k.n, scryptR, scryptP)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	entry := &Entry{
+		Name:      name,
+		PublicKey: key.PublicKey(),
+		Crypto: Crypto{
+			Cipher:     "aes-256-gcm",
+			KDF:        "scrypt",
+			N:          k.n,
+			R:          scryptR,
+			P:          scryptP,
+			Salt:       salt,
+			Nonce:      nonce,
+			Ciphertext: gcm.Seal(nil, nonce, key, []byte(name)),
+		},
+	}
+
+	content, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(k.dir, 0700); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Get wallet by name without decryption
+func (k *Keystore) Get(name string) (*Entry, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(k.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return nil, fmt.Errorf("decode wallet %s: %w", name, err)
+	}
+
+	return &entry, nil
+}
+
+// List all wallets sorted by name
+func (k *Keystore) List() ([]*Entry, error) {
+	files, err := os.ReadDir(k.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	entries := []*Entry{}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), fileExt) {
+			continue
+		}
+
+		entry, err := k.Get(strings.TrimSuffix(file.Name(), fileExt))
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// Decrypt private key of wallet with passphrase
+func (k *Keystore) Decrypt(name string, passphrase []byte) (solana.PrivateKey, error) {
+	entry, err := k.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Crypto.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported kdf %s", entry.Crypto.KDF)
+	}
+
+	gcm, err := newGCM(passphrase, entry.Crypto.Salt, entry.Crypto.N, entry.Crypto.R, entry.Crypto.P)
+	if err != nil {
+		return nil, err
+	}
+
+	// name is authenticated, so renamed wallet file isn't decrypted
+	key, err := gcm.Open(nil, entry.Crypto.Nonce, entry.Crypto.Ciphertext, []byte(name))
+	if err != nil {
+		return nil, ErrBadPassphrase
+	}
+
+	privateKey := solana.PrivateKey(key)
+	if !privateKey.PublicKey().Equals(entry.PublicKey) {
+		return nil, fmt.Errorf("wallet %s public key mismatch", name)
+	}
+
+	return privateKey, nil
+}
+
+// Remove wallet by name
+func (k *Keystore) Remove(name string) error {
+	if _, err := k.Get(name); err != nil {
+		return err
+	}
+
+	return os.Remove(k.path(name))
+}
+
+func (k *Keystore) path(name string) string {
+	return filepath.Join(k.dir, name+fileExt)
+}
+
+func newGCM(passphrase, salt []byte, n, r, p int) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, n, r, p, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func validateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid wallet name %q", name)
+	}
+	return nil
+}
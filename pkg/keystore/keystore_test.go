@@ -0,0 +1,114 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Get keystore in temp dir with cheap scrypt cost
+func testKeystore(t *testing.T) *Keystore {
+	t.Helper()
+
+	ks := New(t.TempDir())
+	ks.n = 1 << 10
+	return ks
+}
+
+func TestCreateDecrypt(t *testing.T) {
+	ks := testKeystore(t)
+	key := solana.NewWallet().PrivateKey
+
+	entry, err := ks.Create("main", key, []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !entry.PublicKey.Equals(key.PublicKey()) {
+		t.Errorf("entry public key = %s, want %s", entry.PublicKey, key.PublicKey())
+	}
+
+	info, err := os.Stat(ks.path("main"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("wallet file mode = %o, want 600", info.Mode().Perm())
+	}
+
+	decrypted, err := ks.Decrypt("main", []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decrypted.String() != key.String() {
+		t.Error("decrypted key differs from created key")
+	}
+
+	if _, err := ks.Create("main", key, []byte("secret")); !errors.Is(err, ErrExists) {
+		t.Errorf("Create of existing wallet error = %v, want %v", err, ErrExists)
+	}
+}
+
+func TestDecryptWrongPassphrase(t *testing.T) {
+	ks := testKeystore(t)
+
+	if _, err := ks.Create("main", solana.NewWallet().PrivateKey, []byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ks.Decrypt("main", []byte("wrong")); !errors.Is(err, ErrBadPassphrase) {
+		t.Errorf("Decrypt error = %v, want %v", err, ErrBadPassphrase)
+	}
+}
+
+func TestDecryptSwappedName(t *testing.T) {
+	ks := testKeystore(t)
+
+	if _, err := ks.Create("main", solana.NewWallet().PrivateKey, []byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(ks.path("main"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		t.Fatal(err)
+	}
+
+	// same encrypted key under other name
+	if err := os.WriteFile(ks.path("renamed"), content, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	entry.Name = "other"
+	swapped, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ks.path("other"), swapped, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"renamed", "other"} {
+		if _, err := ks.Decrypt(name, []byte("secret")); !errors.Is(err, ErrBadPassphrase) {
+			t.Errorf("Decrypt(%s) error = %v, want %v", name, err, ErrBadPassphrase)
+		}
+	}
+}
+
+func TestGetMissing(t *testing.T) {
+	ks := testKeystore(t)
+
+	if _, err := ks.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get error = %v, want %v", err, ErrNotFound)
+	}
+
+	if _, err := ks.Get("../escape"); err == nil {
+		t.Error("Get of invalid name succeeded, want error")
+	}
+}
@@ -0,0 +1,307 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Saber pools registry
+type Registry struct {
+	Addresses RegistryAddresses `json:"addresses"`
+	Pools     []Pool            `json:"pools"`
+}
+
+// Saber program accounts of registry
+type RegistryAddresses struct {
+	Landlord     solana.PublicKey `json:"landlord"`
+	LandlordBase solana.PublicKey `json:"landlordBase"`
+	Rewarder     solana.PublicKey `json:"rewarder"`
+	MintWrapper  solana.PublicKey `json:"mintWrapper"`
+	IouMint      solana.PublicKey `json:"iouMint"`
+	Redeemer     solana.PublicKey `json:"redeemer"`
+	Sbr          solana.PublicKey `json:"sbr"`
+}
+
+// Swap pool of registry
+type Pool struct {
+	ID              string            `json:"id"`
+	Name            string            `json:"name"`
+	Tokens          []Token           `json:"tokens"`
+	TokenIcons      []Token           `json:"tokenIcons"`
+	UnderlyingIcons []Token           `json:"underlyingIcons"`
+	Currency        string            `json:"currency"`
+	LpToken         Token             `json:"lpToken"`
+	PlotKey         string            `json:"plotKey"`
+	Swap            Swap              `json:"swap"`
+	Quarry          OptionalPublicKey `json:"quarry"`
+}
+
+// Token of token list
+type Token struct {
+	Name       string           `json:"name"`
+	Address    solana.PublicKey `json:"address"`
+	Decimals   int              `json:"decimals"`
+	ChainID    int              `json:"chainId"`
+	Symbol     string           `json:"symbol"`
+	LogoURI    string           `json:"logoURI"`
+	Tags       []string         `json:"tags"`
+	Extensions TokenExtensions  `json:"extensions"`
+}
+
+// Token extensions, set of fields depends on token
+type TokenExtensions struct {
+	Currency         string             `json:"currency,omitempty"`
+	Website          string             `json:"website,omitempty"`
+	AssetContract    string             `json:"assetContract,omitempty"`
+	UnderlyingTokens []solana.PublicKey `json:"underlyingTokens,omitempty"`
+	Source           string             `json:"source,omitempty"`
+}
+
+// Swap accounts and state of pool
+type Swap struct {
+	Config SwapConfig `json:"config"`
+	State  SwapState  `json:"state"`
+}
+
+// Swap program accounts of pool
+type SwapConfig struct {
+	SwapAccount    solana.PublicKey `json:"swapAccount"`
+	SwapProgramID  solana.PublicKey `json:"swapProgramID"`
+	TokenProgramID solana.PublicKey `json:"tokenProgramID"`
+	Authority      solana.PublicKey `json:"authority"`
+}
+
+// Swap state at registry generation
+type SwapState struct {
+	IsInitialized       bool              `json:"isInitialized"`
+	IsPaused            bool              `json:"isPaused"`
+	Nonce               int               `json:"nonce"`
+	FutureAdminDeadline int64             `json:"futureAdminDeadline"`
+	FutureAdminAccount  OptionalPublicKey `json:"futureAdminAccount"`
+	AdminAccount        solana.PublicKey  `json:"adminAccount"`
+	TokenA              SwapTokenState    `json:"tokenA"`
+	TokenB              SwapTokenState    `json:"tokenB"`
+	PoolTokenMint       solana.PublicKey  `json:"poolTokenMint"`
+	// Amp factors are encoded as in registry
+	InitialAmpFactor   string   `json:"initialAmpFactor"`
+	TargetAmpFactor    string   `json:"targetAmpFactor"`
+	StartRampTimestamp int64    `json:"startRampTimestamp"`
+	StopRampTimestamp  int64    `json:"stopRampTimestamp"`
+	Fees               SwapFees `json:"fees"`
+}
+
+// Token accounts of swap
+type SwapTokenState struct {
+	AdminFeeAccount solana.PublicKey `json:"adminFeeAccount"`
+	Reserve         solana.PublicKey `json:"reserve"`
+	Mint            solana.PublicKey `json:"mint"`
+}
+
+// Public key which may be empty or null in registry, zero key if it isn't set
+type OptionalPublicKey solana.PublicKey
+
+func (k *OptionalPublicKey) UnmarshalJSON(data []byte) error {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	if s == nil || *s == "" {
+		*k = OptionalPublicKey{}
+		return nil
+	}
+
+	key, err := solana.PublicKeyFromBase58(*s)
+	if err != nil {
+		return err
+	}
+
+	*k = OptionalPublicKey(key)
+	return nil
+}
+
+func (k OptionalPublicKey) MarshalJSON() ([]byte, error) {
+	if k.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(k.String())
+}
+
+// Check key isn't set
+func (k OptionalPublicKey) IsZero() bool {
+	return k.PublicKey().IsZero()
+}
+
+func (k OptionalPublicKey) PublicKey() solana.PublicKey {
+	return solana.PublicKey(k)
+}
+
+func (k OptionalPublicKey) String() string {
+	return k.PublicKey().String()
+}
+
+// Swap fees
+type SwapFees struct {
+	AdminTrade    FeeFraction `json:"adminTrade"`
+	AdminWithdraw FeeFraction `json:"adminWithdraw"`
+	Trade         FeeFraction `json:"trade"`
+	Withdraw      FeeFraction `json:"withdraw"`
+}
+
+// Fee fraction with formatted percent
+type FeeFraction struct {
+	Formatted   string `json:"formatted"`
+	Numerator   string `json:"numerator"`
+	Denominator string `json:"denominator"`
+}
+
+// Get fee in percents
+func (f FeeFraction) Percent() (float64, error) {
+	numerator, err := strconv.ParseUint(f.Numerator, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	denominator, err := strconv.ParseUint(f.Denominator, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if denominator == 0 {
+		return 0, nil
+	}
+
+	return float64(numerator) / float64(denominator) * 100, nil
+}
+
+// Parse registry from json
+func ParseRegistry(data []byte) (*Registry, error) {
+	var registry Registry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, err
+	}
+
+	return &registry, nil
+}
+
+// Find pool by id
+func (r *Registry) PoolByID(id string) (*Pool, error) {
+	for i := range r.Pools {
+		if r.Pools[i].ID == id {
+			return &r.Pools[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("cann't find pool %s in registry", id)
+}
+
+// Find pool by swap account
+func (r *Registry) PoolBySwapAccount(account solana.PublicKey) (*Pool, error) {
+	for i := range r.Pools {
+		if r.Pools[i].Swap.Config.SwapAccount.Equals(account) {
+			return &r.Pools[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("cann't find pool with swap account %s in registry", account)
+}
+
+// Find pool by LP token mint
+func (r *Registry) PoolByLpMint(mint solana.PublicKey) (*Pool, error) {
+	for i := range r.Pools {
+		if r.Pools[i].LpToken.Address.Equals(mint) {
+			return &r.Pools[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("cann't find pool with LP mint %s in registry", mint)
+}
+
+// Find token of pool by symbol, symbol case is ignored
+func (p *Pool) TokenBySymbol(symbol string) (*Token, error) {
+	var found *Token
+	for i := range p.Tokens {
+		if !strings.EqualFold(p.Tokens[i].Symbol, symbol) {
+			continue
+		}
+
+		if found != nil {
+			return nil, fmt.Errorf("token symbol %s is ambiguous in pool %s, use token mint", symbol, p.ID)
+		}
+		found = &p.Tokens[i]
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("cann't find token %s in pool %s", symbol, p.ID)
+	}
+
+	return found, nil
+}
+
+// Pool summary
+type PoolSummary struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Tokens      []TokenSummary   `json:"tokens"`
+	LpToken     TokenSummary     `json:"lp_token"`
+	SwapAccount solana.PublicKey `json:"swap_account"`
+}
+
+// Token summary
+type TokenSummary struct {
+	Name    string           `json:"name"`
+	Symbol  string           `json:"symbol"`
+	Address solana.PublicKey `json:"address"`
+}
+
+func (t Token) Summary() TokenSummary {
+	return TokenSummary{
+		Name:    t.Name,
+		Symbol:  t.Symbol,
+		Address: t.Address,
+	}
+}
+
+func (r *Registry) Summary() []PoolSummary {
+	pools := make([]PoolSummary, 0, len(r.Pools))
+	for _, pool := range r.Pools {
+		summary := PoolSummary{
+			ID:          pool.ID,
+			Name:        pool.Name,
+			LpToken:     pool.LpToken.Summary(),
+			SwapAccount: pool.Swap.Config.SwapAccount,
+		}
+
+		for _, token := range pool.Tokens {
+			summary.Tokens = append(summary.Tokens, token.Summary())
+		}
+
+		pools = append(pools, summary)
+	}
+	return pools
+}
+
+func (r *Registry) ListPools() {
+	for _, pool := range r.Pools {
+		log.Print("\n----------\n")
+
+		log.Printf("Pool id: %s\n", pool.ID)
+
+		log.Print("Tokens:\n")
+		for _, token := range pool.Tokens {
+			log.Printf("\tToken %s (symbol %s) address: %s\n", token.Name, token.Symbol, token.Address)
+		}
+
+		log.Printf("LP token %s (symbol %s) address: %s\n", pool.LpToken.Name, pool.LpToken.Symbol, pool.LpToken.Address)
+
+		log.Printf("Swap account: %s", pool.Swap.Config.SwapAccount)
+
+		log.Print("\n----------\n")
+	}
+}
@@ -0,0 +1,238 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Default time cached registry is used without revalidation
+const DefaultRegistryTTL = time.Hour
+
+// Timeout of registry request
+const registryTimeout = 30 * time.Second
+
+// Registry loader caching registry json with revalidation
+type RegistryLoader struct {
+	// Directory of cached registries
+	CacheDir string
+	// Time cached registry is used without revalidation, zero revalidates on every load
+	TTL time.Duration
+	// Load registry only from cache
+	Offline bool
+	// RPC url of cluster registry is loaded for, it's cached with registry
+	Cluster string
+	// Client of registry requests, default client with timeout
+	Client *http.Client
+}
+
+// Metadata of cached registry
+type registryCacheMeta struct {
+	URL          string    `json:"url"`
+	Cluster      string    `json:"cluster,omitempty"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Fetched      time.Time `json:"fetched"`
+}
+
+// Get default directory of cached registries
+func DefaultRegistryCacheDir() (string, error) {
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cache, "solana_cli", "registry"), nil
+}
+
+// Get registry loader with default cache dir and ttl
+func NewRegistryLoader() (*RegistryLoader, error) {
+	dir, err := DefaultRegistryCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegistryLoader{CacheDir: dir, TTL: DefaultRegistryTTL}, nil
+}
+
+// Load registry from local file
+func LoadRegistryFile(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRegistry(data)
+}
+
+// Load registry from cache or url. Expired cache is revalidated with ETag and
+// Last-Modified, stale cache is used if registry can't be fetched.
+func (l *RegistryLoader) Load(ctx context.Context, url string) (*Registry, error) {
+	dataPath, metaPath := l.cachePaths(url)
+
+	cached, meta := readRegistryCache(dataPath, metaPath)
+	if l.Offline {
+		if cached == nil {
+			return nil, fmt.Errorf("registry %s isn't cached, cann't load it offline", url)
+		}
+		return ParseRegistry(cached)
+	}
+
+	if cached != nil && time.Since(meta.Fetched) < l.TTL {
+		return ParseRegistry(cached)
+	}
+
+	data, fresh, err := l.fetch(ctx, url, cached, meta)
+	if err != nil {
+		if cached != nil && ctx.Err() == nil {
+			return ParseRegistry(cached)
+		}
+		return nil, err
+	}
+
+	registry, err := ParseRegistry(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// registry is usable without cache
+	fresh.Fetched = time.Now()
+	fresh.Cluster = l.Cluster
+	if err := writeRegistryCache(dataPath, metaPath, data, fresh); err != nil {
+		log.Printf("Warning: cann't cache registry %s: %v", url, err)
+	}
+
+	return registry, nil
+}
+
+// Load registry most recently fetched for cluster from cache, url of registry is returned with it
+func (l *RegistryLoader) LoadCluster(cluster string) (*Registry, string, error) {
+	metaPaths, err := filepath.Glob(filepath.Join(l.CacheDir, "*.meta.json"))
+	if err != nil {
+		return nil, "", err
+	}
+
+	var latest []byte
+	var latestMeta registryCacheMeta
+	for _, metaPath := range metaPaths {
+		dataPath := strings.TrimSuffix(metaPath, ".meta.json") + ".json"
+
+		data, meta := readRegistryCache(dataPath, metaPath)
+		if data != nil && meta.Cluster == cluster && (latest == nil || meta.Fetched.After(latestMeta.Fetched)) {
+			latest, latestMeta = data, meta
+		}
+	}
+
+	if latest == nil {
+		return nil, "", fmt.Errorf("registry of cluster %s isn't cached, set registry url", cluster)
+	}
+
+	registry, err := ParseRegistry(latest)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return registry, latestMeta.URL, nil
+}
+
+// Fetch registry, not modified registry gives cached data
+func (l *RegistryLoader) fetch(ctx context.Context, url string, cached []byte, meta registryCacheMeta) ([]byte, registryCacheMeta, error) {
+	client := l.Client
+	if client == nil {
+		client = &http.Client{Timeout: registryTimeout}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	if cached != nil {
+		if meta.ETag != "" {
+			req.Header.Set("If-None-Match", meta.ETag)
+		}
+		if meta.LastModified != "" {
+			req.Header.Set("If-Modified-Since", meta.LastModified)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, meta, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return cached, meta, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, meta, fmt.Errorf("registry %s: %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	return data, registryCacheMeta{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// Get paths of cached registry data and metadata for url
+func (l *RegistryLoader) cachePaths(url string) (string, string) {
+	sum := sha256.Sum256([]byte(url))
+	name := hex.EncodeToString(sum[:8])
+
+	return filepath.Join(l.CacheDir, name+".json"), filepath.Join(l.CacheDir, name+".meta.json")
+}
+
+// Read cached registry, missing or broken cache gives nil data
+func readRegistryCache(dataPath, metaPath string) ([]byte, registryCacheMeta) {
+	var meta registryCacheMeta
+
+	data, err := os.ReadFile(dataPath)
+	if err != nil {
+		return nil, meta
+	}
+
+	metaData, err := os.ReadFile(metaPath)
+	if err != nil {
+		return nil, meta
+	}
+
+	if err := json.Unmarshal(metaData, &meta); err != nil {
+		return nil, meta
+	}
+
+	return data, meta
+}
+
+func writeRegistryCache(dataPath, metaPath string, data []byte, meta registryCacheMeta) error {
+	if err := os.MkdirAll(filepath.Dir(dataPath), 0700); err != nil {
+		return err
+	}
+
+	metaData, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(dataPath, data, 0600); err != nil {
+		return err
+	}
+
+	return os.WriteFile(metaPath, metaData, 0600)
+}
@@ -0,0 +1,167 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Registry server counting requests, it answers not modified to matching ETag
+type registryServer struct {
+	*httptest.Server
+	data     []byte
+	requests int
+	notMod   int
+}
+
+func newRegistryServer(t *testing.T) *registryServer {
+	data, err := os.ReadFile("testdata/registry.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &registryServer{data: data}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			s.notMod++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Write(s.data)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func TestLoadCacheHit(t *testing.T) {
+	server := newRegistryServer(t)
+	loader := &RegistryLoader{CacheDir: t.TempDir(), TTL: time.Hour}
+
+	for i := 0; i < 2; i++ {
+		registry, err := loader.Load(context.Background(), server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(registry.Pools) != 2 {
+			t.Fatalf("pools = %d, want 2", len(registry.Pools))
+		}
+	}
+
+	if server.requests != 1 {
+		t.Errorf("requests = %d, want 1", server.requests)
+	}
+}
+
+func TestLoadRevalidate(t *testing.T) {
+	server := newRegistryServer(t)
+	loader := &RegistryLoader{CacheDir: t.TempDir()}
+
+	for i := 0; i < 2; i++ {
+		registry, err := loader.Load(context.Background(), server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(registry.Pools) != 2 {
+			t.Fatalf("pools = %d, want 2", len(registry.Pools))
+		}
+	}
+
+	if server.requests != 2 || server.notMod != 1 {
+		t.Errorf("requests = %d, not modified = %d, want 2 and 1", server.requests, server.notMod)
+	}
+}
+
+func TestLoadStale(t *testing.T) {
+	server := newRegistryServer(t)
+	loader := &RegistryLoader{CacheDir: t.TempDir()}
+
+	if _, err := loader.Load(context.Background(), server.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	server.Close()
+	registry, err := loader.Load(context.Background(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(registry.Pools) != 2 {
+		t.Errorf("pools = %d, want 2", len(registry.Pools))
+	}
+}
+
+func TestLoadOffline(t *testing.T) {
+	server := newRegistryServer(t)
+	dir := t.TempDir()
+
+	offline := &RegistryLoader{CacheDir: dir, Offline: true}
+	if _, err := offline.Load(context.Background(), server.URL); err == nil {
+		t.Error("offline Load without cache succeeded, want error")
+	}
+
+	loader := &RegistryLoader{CacheDir: dir}
+	if _, err := loader.Load(context.Background(), server.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := offline.Load(context.Background(), server.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	if server.requests != 1 {
+		t.Errorf("requests = %d, want 1", server.requests)
+	}
+}
+
+func TestLoadCluster(t *testing.T) {
+	server := newRegistryServer(t)
+	dir := t.TempDir()
+
+	loader := &RegistryLoader{CacheDir: dir, Cluster: "http://127.0.0.1:8899"}
+	if _, _, err := loader.LoadCluster(loader.Cluster); err == nil {
+		t.Error("LoadCluster without cache succeeded, want error")
+	}
+
+	if _, err := loader.Load(context.Background(), server.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	_, url, err := loader.LoadCluster(loader.Cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != server.URL {
+		t.Errorf("cached url = %s, want %s", url, server.URL)
+	}
+
+	// registry of other cluster isn't used
+	if _, _, err := loader.LoadCluster("http://127.0.0.1:9899"); err == nil {
+		t.Error("LoadCluster of other cluster succeeded, want error")
+	}
+}
+
+func TestLoadCacheWriteError(t *testing.T) {
+	server := newRegistryServer(t)
+
+	// cache dir is a file, so cache cann't be written
+	dir := filepath.Join(t.TempDir(), "cache")
+	if err := os.WriteFile(dir, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := &RegistryLoader{CacheDir: dir}
+	registry, err := loader.Load(context.Background(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(registry.Pools) != 2 {
+		t.Errorf("pools = %d, want 2", len(registry.Pools))
+	}
+}
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+const optionalAddressesRegistry = `{
+	"addresses": {"sbr": "Saber2gLauYim4Mvftnrasomsv6NvAuncvMEZwcLpD1"},
+	"pools": [
+		{
+			"id": "empty",
+			"quarry": "",
+			"swap": {"state": {"futureAdminAccount": ""}}
+		},
+		{
+			"id": "null",
+			"quarry": null,
+			"swap": {"state": {"futureAdminAccount": null}}
+		},
+		{
+			"id": "missing",
+			"swap": {"state": {}}
+		},
+		{
+			"id": "set",
+			"quarry": "Saber2gLauYim4Mvftnrasomsv6NvAuncvMEZwcLpD1",
+			"swap": {"state": {"futureAdminAccount": "Saber2gLauYim4Mvftnrasomsv6NvAuncvMEZwcLpD1"}}
+		}
+	]
+}`
+
+func TestParseRegistryOptionalAddresses(t *testing.T) {
+	registry, err := ParseRegistry([]byte(optionalAddressesRegistry))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sbr := solana.MustPublicKeyFromBase58("Saber2gLauYim4Mvftnrasomsv6NvAuncvMEZwcLpD1")
+
+	for _, id := range []string{"empty", "null", "missing"} {
+		pool, err := registry.PoolByID(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !pool.Quarry.IsZero() {
+			t.Errorf("pool %s quarry = %s, want unset", id, pool.Quarry)
+		}
+		if !pool.Swap.State.FutureAdminAccount.IsZero() {
+			t.Errorf("pool %s future admin = %s, want unset", id, pool.Swap.State.FutureAdminAccount)
+		}
+	}
+
+	pool, err := registry.PoolByID("set")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !pool.Quarry.PublicKey().Equals(sbr) {
+		t.Errorf("quarry = %s, want %s", pool.Quarry, sbr)
+	}
+	if !pool.Swap.State.FutureAdminAccount.PublicKey().Equals(sbr) {
+		t.Errorf("future admin = %s, want %s", pool.Swap.State.FutureAdminAccount, sbr)
+	}
+}
+
+func TestParseRegistryInvalidAddress(t *testing.T) {
+	if _, err := ParseRegistry([]byte(`{"pools": [{"quarry": "not a key"}]}`)); err == nil {
+		t.Error("ParseRegistry of invalid quarry succeeded, want error")
+	}
+}
+
+func TestOptionalPublicKeyMarshal(t *testing.T) {
+	tests := []struct {
+		key  OptionalPublicKey
+		want string
+	}{
+		{OptionalPublicKey{}, "null"},
+		{OptionalPublicKey(solana.MustPublicKeyFromBase58("Saber2gLauYim4Mvftnrasomsv6NvAuncvMEZwcLpD1")), `"Saber2gLauYim4Mvftnrasomsv6NvAuncvMEZwcLpD1"`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(data) != tt.want {
+			t.Errorf("Marshal = %s, want %s", data, tt.want)
+		}
+	}
+}
+
+func TestParseRegistrySnapshot(t *testing.T) {
+	registry, err := LoadRegistryFile("testdata/registry.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(registry.Pools) != 2 {
+		t.Fatalf("pools = %d, want 2", len(registry.Pools))
+	}
+
+	pool, err := registry.PoolBySwapAccount(solana.MustPublicKeyFromBase58("Dk3pDd9s3JoMavrwzXSYQ9VZ3k7W3WcDepXYN18GgPeW"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.ID != "usdc_pai" {
+		t.Errorf("pool of swap account = %s, want usdc_pai", pool.ID)
+	}
+
+	pool, err = registry.PoolByLpMint(solana.MustPublicKeyFromBase58("43ftvHCpkvbTfBGDKkR6MaX4NGqCcDZkob22iWPW71gU"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.ID != "usdc_usdt" {
+		t.Errorf("pool of LP mint = %s, want usdc_usdt", pool.ID)
+	}
+
+	if _, err := registry.PoolByID("missing"); err == nil {
+		t.Error("PoolByID of missing pool succeeded, want error")
+	}
+}
+
+func TestPoolTokenBySymbol(t *testing.T) {
+	registry, err := LoadRegistryFile("testdata/registry.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool, err := registry.PoolByID("usdc_pai")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := pool.TokenBySymbol("pai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Address.String() != "Ea5SjE2Y6yvCeW5dYTn7PYMuW5ikXkvbGdcmSnXeaLjS" {
+		t.Errorf("PAI address = %s", token.Address)
+	}
+
+	if _, err := pool.TokenBySymbol("USDT"); err == nil {
+		t.Error("TokenBySymbol of token from other pool succeeded, want error")
+	}
+
+	pool.Tokens[1].Symbol = "usdc"
+	if _, err := pool.TokenBySymbol("USDC"); err == nil {
+		t.Error("TokenBySymbol of ambiguous symbol succeeded, want error")
+	}
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	"math/big"
 
 	"github.com/gagliardetto/solana-go"
 )
@@ -67,3 +68,85 @@ func (s *SwapInfo) HasToken(token solana.PublicKey) (*SwapTokenInfo, error) {
 
 	return nil, errors.New("cann't find token in swap info")
 }
+
+// Get other token of swap pair or error
+func (s *SwapInfo) PairToken(token solana.PublicKey) (*SwapTokenInfo, error) {
+	if token == s.TokenAMint {
+		return s.HasToken(s.TokenBMint)
+	}
+
+	if token == s.TokenBMint {
+		return s.HasToken(s.TokenAMint)
+	}
+
+	return nil, errors.New("cann't find token in swap info")
+}
+
+// Get effective amp factor at unix time ts
+func (s *SwapInfo) AmpFactor(ts int64) uint64 {
+	if ts >= s.StopRampTs {
+		return s.TargetAmpFactor
+	}
+
+	if ts <= s.StartRampTs {
+		return s.InitialAmpFactor
+	}
+
+	timeRange := uint64(s.StopRampTs - s.StartRampTs)
+	timeDelta := uint64(ts - s.StartRampTs)
+
+	if s.TargetAmpFactor >= s.InitialAmpFactor {
+		ampRange := s.TargetAmpFactor - s.InitialAmpFactor
+		return s.InitialAmpFactor + MulDiv(ampRange, timeDelta, timeRange)
+	}
+
+	ampRange := s.InitialAmpFactor - s.TargetAmpFactor
+	return s.InitialAmpFactor - MulDiv(ampRange, timeDelta, timeRange)
+}
+
+// Check amp factor ramp is running at unix time ts
+func (s *SwapInfo) IsRamping(ts int64) bool {
+	return ts >= s.StartRampTs && ts < s.StopRampTs
+}
+
+// Trade fee for amount
+func (f *Fees) TradeFee(amount uint64) uint64 {
+	return MulDiv(amount, f.TradeFeeNumerator, f.TradeFeeDenominator)
+}
+
+// Trade fee for amount adjusted to number of tokens in pool
+func (f *Fees) NormalizedTradeFee(nCoins, amount uint64) uint64 {
+	if nCoins < 2 {
+		return 0
+	}
+
+	numerator := f.TradeFeeNumerator * nCoins / (4 * (nCoins - 1))
+	return MulDiv(amount, numerator, f.TradeFeeDenominator)
+}
+
+// Admin part of trade fee
+func (f *Fees) AdminTradeFee(fee uint64) uint64 {
+	return MulDiv(fee, f.AdminTradeFeeNumerator, f.AdminTradeFeeDenominator)
+}
+
+// Withdraw fee for amount
+func (f *Fees) WithdrawFee(amount uint64) uint64 {
+	return MulDiv(amount, f.WithdrawFeeNumerator, f.WithdrawFeeDenominator)
+}
+
+// Admin part of withdraw fee
+func (f *Fees) AdminWithdrawFee(fee uint64) uint64 {
+	return MulDiv(fee, f.AdminWithdrawFeeNumerator, f.AdminWithdrawDeeDenominator)
+}
+
+// Get amount * numerator / denominator without intermediate overflow, zero denominator gives zero
+func MulDiv(amount, numerator, denominator uint64) uint64 {
+	if denominator == 0 {
+		return 0
+	}
+
+	out := new(big.Int).SetUint64(amount)
+	out.Mul(out, new(big.Int).SetUint64(numerator))
+	out.Quo(out, new(big.Int).SetUint64(denominator))
+	return out.Uint64()
+}
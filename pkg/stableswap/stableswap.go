@@ -0,0 +1,355 @@
+package stableswap
+
+import (
+	"errors"
+	"math/big"
+
+	"solana/pkg/model"
+)
+
+// Number of tokens in saber pool
+const nCoins = 2
+
+// Max iterations for D and Y computation
+const maxIterations = 256
+
+// Basis points in one
+const bpsDenominator = 10_000
+
+var (
+	bigOne    = big.NewInt(1)
+	bigCoins  = big.NewInt(nCoins)
+	errAmount = errors.New("amount is out of pool range")
+	errAmp    = errors.New("amp factor is zero")
+)
+
+// StableSwap invariant calculator
+type StableSwap struct {
+	Amp  uint64
+	Fees *model.Fees
+}
+
+// Swap result
+type SwapResult struct {
+	AmountIn       uint64
+	AmountOut      uint64
+	TradeFee       uint64
+	AdminFee       uint64
+	NewSource      uint64
+	NewDestination uint64
+}
+
+// Withdraw result
+type WithdrawResult struct {
+	PoolTokenAmount uint64
+	AmountA         uint64
+	AmountB         uint64
+	FeeA            uint64
+	FeeB            uint64
+}
+
+// Withdraw one result
+type WithdrawOneResult struct {
+	PoolTokenAmount uint64
+	AmountOut       uint64
+	TradeFee        uint64
+	WithdrawFee     uint64
+	AdminFee        uint64
+}
+
+// Get new calculator from swap info at unix time ts
+func New(info *model.SwapInfo, ts int64) *StableSwap {
+	fees := info.Fees
+	if fees == nil {
+		fees = &model.Fees{}
+	}
+
+	return &StableSwap{
+		Amp:  info.AmpFactor(ts),
+		Fees: fees,
+	}
+}
+
+// Compute stable swap invariant D
+func (s *StableSwap) ComputeD(amountA, amountB uint64) *big.Int {
+	a := new(big.Int).SetUint64(amountA)
+	b := new(big.Int).SetUint64(amountB)
+
+	sum := new(big.Int).Add(a, b)
+	if sum.Sign() == 0 || a.Sign() == 0 || b.Sign() == 0 {
+		return new(big.Int)
+	}
+
+	aTimesCoins := new(big.Int).Mul(a, bigCoins)
+	bTimesCoins := new(big.Int).Mul(b, bigCoins)
+
+	d := new(big.Int).Set(sum)
+	for i := 0; i < maxIterations; i++ {
+		dProd := new(big.Int).Set(d)
+		dProd.Mul(dProd, d).Quo(dProd, aTimesCoins)
+		dProd.Mul(dProd, d).Quo(dProd, bTimesCoins)
+
+		dPrev := d
+		d = s.computeNextD(d, dProd, sum)
+
+		if closeEnough(d, dPrev) {
+			break
+		}
+	}
+
+	return d
+}
+
+// Get virtual price of pool token: invariant D per pool token
+func (s *StableSwap) VirtualPrice(reserveA, reserveB, poolTokenSupply uint64) float64 {
+	if poolTokenSupply == 0 {
+		return 0
+	}
+
+	d := new(big.Float).SetInt(s.ComputeD(reserveA, reserveB))
+	price, _ := d.Quo(d, new(big.Float).SetUint64(poolTokenSupply)).Float64()
+	return price
+}
+
+// Compute new amount of token Y for amount of token X and invariant D
+func (s *StableSwap) ComputeY(x uint64, d *big.Int) *big.Int {
+	ann := s.ann()
+	bigX := new(big.Int).SetUint64(x)
+
+	// c = d^3 / (x * n * ann * n)
+	c := new(big.Int).Set(d)
+	c.Mul(c, d).Quo(c, new(big.Int).Mul(bigX, bigCoins))
+	c.Mul(c, d).Quo(c, new(big.Int).Mul(ann, bigCoins))
+
+	// b = d / ann + x
+	b := new(big.Int).Quo(d, ann)
+	b.Add(b, bigX)
+
+	y := new(big.Int).Set(d)
+	for i := 0; i < maxIterations; i++ {
+		yPrev := y
+
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+
+		denominator := new(big.Int).Lsh(y, 1)
+		denominator.Add(denominator, b).Sub(denominator, d)
+		if denominator.Sign() <= 0 {
+			break
+		}
+
+		y = numerator.Quo(numerator, denominator)
+
+		if closeEnough(y, yPrev) {
+			break
+		}
+	}
+
+	return y
+}
+
+// Compute output amount for swap from source to destination reserve
+func (s *StableSwap) SwapTo(amountIn, sourceReserve, destinationReserve uint64) (*SwapResult, error) {
+	if s.Amp == 0 {
+		return nil, errAmp
+	}
+
+	newSource := sourceReserve + amountIn
+	if newSource < sourceReserve {
+		return nil, errAmount
+	}
+
+	d := s.ComputeD(sourceReserve, destinationReserve)
+	if d.Sign() == 0 {
+		return nil, errAmount
+	}
+
+	y := s.ComputeY(newSource, d)
+	if !y.IsUint64() || y.Uint64() > destinationReserve {
+		return nil, errAmount
+	}
+
+	dy := destinationReserve - y.Uint64()
+	tradeFee := s.Fees.TradeFee(dy)
+	adminFee := s.Fees.AdminTradeFee(tradeFee)
+	amountOut := dy - tradeFee
+
+	return &SwapResult{
+		AmountIn:       amountIn,
+		AmountOut:      amountOut,
+		TradeFee:       tradeFee,
+		AdminFee:       adminFee,
+		NewSource:      newSource,
+		NewDestination: destinationReserve - amountOut - adminFee,
+	}, nil
+}
+
+// Compute token amounts for withdraw of pool tokens at the current ratio
+func (s *StableSwap) Withdraw(poolTokenAmount, poolTokenSupply, reserveA, reserveB uint64) (*WithdrawResult, error) {
+	if poolTokenSupply == 0 || poolTokenAmount > poolTokenSupply {
+		return nil, errAmount
+	}
+
+	valueA := model.MulDiv(reserveA, poolTokenAmount, poolTokenSupply)
+	valueB := model.MulDiv(reserveB, poolTokenAmount, poolTokenSupply)
+
+	feeA := s.Fees.WithdrawFee(valueA)
+	feeB := s.Fees.WithdrawFee(valueB)
+
+	return &WithdrawResult{
+		PoolTokenAmount: poolTokenAmount,
+		AmountA:         valueA - feeA,
+		AmountB:         valueB - feeB,
+		FeeA:            feeA,
+		FeeB:            feeB,
+	}, nil
+}
+
+// Compute base token amount for withdraw of pool tokens into one token
+func (s *StableSwap) WithdrawOne(poolTokenAmount, poolTokenSupply, baseReserve, quoteReserve uint64) (*WithdrawOneResult, error) {
+	if s.Amp == 0 {
+		return nil, errAmp
+	}
+
+	if poolTokenSupply == 0 || poolTokenAmount > poolTokenSupply {
+		return nil, errAmount
+	}
+
+	d0 := s.ComputeD(baseReserve, quoteReserve)
+	if d0.Sign() == 0 {
+		return nil, errAmount
+	}
+
+	// d1 = d0 - pool_token_amount * d0 / pool_token_supply
+	d1 := new(big.Int).SetUint64(poolTokenAmount)
+	d1.Mul(d1, d0).Quo(d1, new(big.Int).SetUint64(poolTokenSupply))
+	d1.Sub(d0, d1)
+
+	newBase, err := toUint64(s.ComputeY(quoteReserve, d1))
+	if err != nil {
+		return nil, err
+	}
+
+	// expected_base_amount = base_reserve * d1 / d0 - new_base_amount
+	scaledBase, err := toUint64(scale(baseReserve, d1, d0))
+	if err != nil {
+		return nil, err
+	}
+	expectedBase, err := sub(scaledBase, newBase)
+	if err != nil {
+		return nil, err
+	}
+
+	// expected_quote_amount = quote_reserve - quote_reserve * d1 / d0
+	scaledQuote, err := toUint64(scale(quoteReserve, d1, d0))
+	if err != nil {
+		return nil, err
+	}
+	expectedQuote, err := sub(quoteReserve, scaledQuote)
+	if err != nil {
+		return nil, err
+	}
+
+	// reserves reduced by fee of expected amounts
+	newBaseFee, err := sub(baseReserve, s.Fees.NormalizedTradeFee(nCoins, expectedBase))
+	if err != nil {
+		return nil, err
+	}
+
+	newQuoteFee, err := sub(quoteReserve, s.Fees.NormalizedTradeFee(nCoins, expectedQuote))
+	if err != nil || newQuoteFee == 0 {
+		return nil, errAmount
+	}
+
+	y, err := toUint64(s.ComputeY(newQuoteFee, d1))
+	if err != nil {
+		return nil, err
+	}
+
+	// withdraw less to account for rounding errors, as the program does
+	dy, err := sub(newBaseFee, y)
+	if err != nil {
+		return nil, err
+	}
+	dy, err = sub(dy, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	dy0, err := sub(baseReserve, newBase)
+	if err != nil {
+		return nil, err
+	}
+
+	tradeFee, err := sub(dy0, dy)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawFee := s.Fees.WithdrawFee(dy)
+
+	return &WithdrawOneResult{
+		PoolTokenAmount: poolTokenAmount,
+		AmountOut:       dy - withdrawFee,
+		TradeFee:        tradeFee,
+		WithdrawFee:     withdrawFee,
+		AdminFee:        s.Fees.AdminTradeFee(tradeFee) + s.Fees.AdminWithdrawFee(withdrawFee),
+	}, nil
+}
+
+// Get minimum amount with slippage in basis points
+func MinimumAmountOut(amount, slippageBps uint64) uint64 {
+	if slippageBps >= bpsDenominator {
+		return 0
+	}
+
+	out := new(big.Int).SetUint64(amount)
+	out.Mul(out, big.NewInt(int64(bpsDenominator-slippageBps)))
+	out.Quo(out, big.NewInt(bpsDenominator))
+	return out.Uint64()
+}
+
+func (s *StableSwap) ann() *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(s.Amp), bigCoins)
+}
+
+func (s *StableSwap) computeNextD(dInit, dProd, sum *big.Int) *big.Int {
+	ann := s.ann()
+	leverage := new(big.Int).Mul(sum, ann)
+
+	// (leverage + d_prod * n) * d
+	numerator := new(big.Int).Mul(dProd, bigCoins)
+	numerator.Add(numerator, leverage).Mul(numerator, dInit)
+
+	// (ann - 1) * d + (n + 1) * d_prod
+	denominator := new(big.Int).Sub(ann, bigOne)
+	denominator.Mul(denominator, dInit)
+	denominator.Add(denominator, new(big.Int).Mul(dProd, big.NewInt(nCoins+1)))
+
+	return numerator.Quo(numerator, denominator)
+}
+
+func scale(amount uint64, numerator, denominator *big.Int) *big.Int {
+	out := new(big.Int).SetUint64(amount)
+	out.Mul(out, numerator)
+	return out.Quo(out, denominator)
+}
+
+func toUint64(x *big.Int) (uint64, error) {
+	if x.Sign() < 0 || !x.IsUint64() {
+		return 0, errAmount
+	}
+	return x.Uint64(), nil
+}
+
+func sub(a, b uint64) (uint64, error) {
+	if b > a {
+		return 0, errAmount
+	}
+	return a - b, nil
+}
+
+func closeEnough(a, b *big.Int) bool {
+	diff := new(big.Int).Sub(a, b)
+	return diff.CmpAbs(bigOne) <= 0
+}
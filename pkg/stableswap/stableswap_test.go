@@ -0,0 +1,313 @@
+package stableswap
+
+import (
+	"math/big"
+	"testing"
+
+	"solana/pkg/model"
+)
+
+// Reference values are computed with saber stable-swap-math algorithm
+
+// Saber mainnet pool fees: 0.04% trade, 0.5% withdraw, half of fees to admin
+var testFees = &model.Fees{
+	AdminTradeFeeNumerator:      50,
+	AdminTradeFeeDenominator:    100,
+	AdminWithdrawFeeNumerator:   50,
+	AdminWithdrawDeeDenominator: 100,
+	TradeFeeNumerator:           4,
+	TradeFeeDenominator:         10_000,
+	WithdrawFeeNumerator:        50,
+	WithdrawFeeDenominator:      10_000,
+}
+
+func bigInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+
+	x, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		t.Fatalf("bad big int %s", s)
+	}
+	return x
+}
+
+func TestComputeD(t *testing.T) {
+	tests := []struct {
+		name    string
+		amp     uint64
+		amountA uint64
+		amountB uint64
+		d       string
+	}{
+		{"empty pool", 100, 0, 0, "0"},
+		{"zero reserve", 100, 1_000_000_000, 0, "0"},
+		{"balanced", 100, 1_000_000_000, 1_000_000_000, "2000000000"},
+		{"imbalanced", 100, 1_000_000_000, 10_000_000, "917914126"},
+		{"imbalanced low amp", 1, 1_000_000_000, 10_000_000, "401533032"},
+		{"large reserves", 1188, 1046129065254161082, 1250710035549196829, "2296831376791770037"},
+		{"large reserves low amp", 9, 862538457714585493, 492548187909826733, "1349690409671689975"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &StableSwap{Amp: tt.amp, Fees: testFees}
+			if d := s.ComputeD(tt.amountA, tt.amountB); d.Cmp(bigInt(t, tt.d)) != 0 {
+				t.Errorf("ComputeD(%d, %d) = %s, want %s", tt.amountA, tt.amountB, d, tt.d)
+			}
+		})
+	}
+}
+
+func TestComputeY(t *testing.T) {
+	tests := []struct {
+		name string
+		amp  uint64
+		x    uint64
+		d    string
+		y    string
+	}{
+		{"balanced", 100, 1_100_000_000, "2000000000", "900099889"},
+		{"large reserves", 1188, 2045250484898639148, "2296831376791770037", "253077307234641934"},
+		{"large reserves low amp", 9, 815577754938955939, "1349690409671689975", "537087634370029135"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &StableSwap{Amp: tt.amp, Fees: testFees}
+			if y := s.ComputeY(tt.x, bigInt(t, tt.d)); y.Cmp(bigInt(t, tt.y)) != 0 {
+				t.Errorf("ComputeY(%d, %s) = %s, want %s", tt.x, tt.d, y, tt.y)
+			}
+		})
+	}
+}
+
+func TestSwapTo(t *testing.T) {
+	tests := []struct {
+		name        string
+		amp         uint64
+		amountIn    uint64
+		source      uint64
+		destination uint64
+		want        SwapResult
+	}{
+		{
+			name: "balanced", amp: 100, amountIn: 1_000_000, source: 1_000_000_000, destination: 1_000_000_000,
+			want: SwapResult{AmountOut: 999592, TradeFee: 399, AdminFee: 199, NewSource: 1_001_000_000, NewDestination: 999000209},
+		},
+		{
+			name: "imbalanced", amp: 100, amountIn: 1_000_000, source: 1_000_000_000, destination: 10_000_000,
+			want: SwapResult{AmountOut: 101898, TradeFee: 40, AdminFee: 20, NewSource: 1_001_000_000, NewDestination: 9898082},
+		},
+		{
+			name: "min amp", amp: 1, amountIn: 1_000_000, source: 1_000_000_000, destination: 1_000_000_000,
+			want: SwapResult{AmountOut: 999102, TradeFee: 399, AdminFee: 199, NewSource: 1_001_000_000, NewDestination: 999000699},
+		},
+		{
+			name: "large trade", amp: 2000, amountIn: 500_000_000, source: 1_000_000_000, destination: 1_000_000_000,
+			want: SwapResult{AmountOut: 499633623, TradeFee: 199933, AdminFee: 99966, NewSource: 1_500_000_000, NewDestination: 500266411},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &StableSwap{Amp: tt.amp, Fees: testFees}
+			got, err := s.SwapTo(tt.amountIn, tt.source, tt.destination)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.want.AmountIn = tt.amountIn
+			if *got != tt.want {
+				t.Errorf("SwapTo(%d, %d, %d) = %+v, want %+v", tt.amountIn, tt.source, tt.destination, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSwapToErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		amp         uint64
+		source      uint64
+		destination uint64
+	}{
+		{"zero amp", 0, 1_000_000_000, 1_000_000_000},
+		{"empty pool", 100, 0, 0},
+		{"zero destination reserve", 100, 1_000_000_000, 0},
+		{"zero source reserve", 100, 0, 1_000_000_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &StableSwap{Amp: tt.amp, Fees: testFees}
+			if _, err := s.SwapTo(1_000_000, tt.source, tt.destination); err == nil {
+				t.Error("SwapTo succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestWithdraw(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount uint64
+		supply uint64
+		a      uint64
+		b      uint64
+		want   WithdrawResult
+	}{
+		{
+			name: "balanced", amount: 100_000_000, supply: 2_000_000_000, a: 1_000_000_000, b: 1_000_000_000,
+			want: WithdrawResult{AmountA: 49_750_000, AmountB: 49_750_000, FeeA: 250_000, FeeB: 250_000},
+		},
+		{
+			name: "whole imbalanced pool", amount: 2_000_000_000, supply: 2_000_000_000, a: 1_000_000_000, b: 10_000_000,
+			want: WithdrawResult{AmountA: 995_000_000, AmountB: 9_950_000, FeeA: 5_000_000, FeeB: 50_000},
+		},
+		{
+			name: "rounding down", amount: 1, supply: 3, a: 10, b: 10,
+			want: WithdrawResult{AmountA: 3, AmountB: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &StableSwap{Amp: 100, Fees: testFees}
+			got, err := s.Withdraw(tt.amount, tt.supply, tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.want.PoolTokenAmount = tt.amount
+			if *got != tt.want {
+				t.Errorf("Withdraw(%d, %d, %d, %d) = %+v, want %+v", tt.amount, tt.supply, tt.a, tt.b, *got, tt.want)
+			}
+		})
+	}
+
+	s := &StableSwap{Amp: 100, Fees: testFees}
+	if _, err := s.Withdraw(1, 0, 0, 0); err == nil {
+		t.Error("Withdraw from empty pool succeeded, want error")
+	}
+	if _, err := s.Withdraw(3, 2, 10, 10); err == nil {
+		t.Error("Withdraw over supply succeeded, want error")
+	}
+}
+
+func TestAmpFactorRamp(t *testing.T) {
+	up := &model.SwapInfo{InitialAmpFactor: 100, TargetAmpFactor: 200, StartRampTs: 1000, StopRampTs: 2000}
+	down := &model.SwapInfo{InitialAmpFactor: 200, TargetAmpFactor: 100, StartRampTs: 1000, StopRampTs: 2000}
+
+	tests := []struct {
+		name string
+		info *model.SwapInfo
+		ts   int64
+		amp  uint64
+	}{
+		{"before ramp", up, 999, 100},
+		{"ramp start", up, 1000, 100},
+		{"ramp middle", up, 1500, 150},
+		{"ramp stop", up, 2000, 200},
+		{"after ramp", up, 3000, 200},
+		{"down ramp start", down, 1000, 200},
+		{"down ramp quarter", down, 1250, 175},
+		{"down ramp stop", down, 2000, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if amp := New(tt.info, tt.ts).Amp; amp != tt.amp {
+				t.Errorf("amp at %d = %d, want %d", tt.ts, amp, tt.amp)
+			}
+		})
+	}
+}
+
+func TestMinimumAmountOut(t *testing.T) {
+	tests := []struct {
+		amount      uint64
+		slippageBps uint64
+		want        uint64
+	}{
+		{1_000_000, 0, 1_000_000},
+		{1_000_000, 50, 995_000},
+		{999, 1, 998},
+		{1_000_000, 10_000, 0},
+	}
+
+	for _, tt := range tests {
+		if got := MinimumAmountOut(tt.amount, tt.slippageBps); got != tt.want {
+			t.Errorf("MinimumAmountOut(%d, %d) = %d, want %d", tt.amount, tt.slippageBps, got, tt.want)
+		}
+	}
+}
+
+func TestWithdrawOne(t *testing.T) {
+	tests := []struct {
+		name   string
+		amp    uint64
+		amount uint64
+		supply uint64
+		base   uint64
+		quote  uint64
+		want   WithdrawOneResult
+	}{
+		{
+			name: "balanced", amp: 100, amount: 100_000_000, supply: 2_000_000_000, base: 1_000_000_000, quote: 1_000_000_000,
+			want: WithdrawOneResult{AmountOut: 99454134, TradeFee: 19985, WithdrawFee: 499769, AdminFee: 259876},
+		},
+		{
+			name: "imbalanced", amp: 100, amount: 100_000_000, supply: 2_000_000_000, base: 1_000_000_000, quote: 10_000_000,
+			want: WithdrawOneResult{AmountOut: 54386698, TradeFee: 1827, WithdrawFee: 273299, AdminFee: 137562},
+		},
+		{
+			name: "dust", amp: 100, amount: 100, supply: 2_000_000_000, base: 1_000_000_000, quote: 1_000_000_000,
+			want: WithdrawOneResult{AmountOut: 99, TradeFee: 1},
+		},
+		{
+			name: "min amp", amp: 1, amount: 500_000_000, supply: 2_000_000_000, base: 1_000_000_000, quote: 1_000_000_000,
+			want: WithdrawOneResult{AmountOut: 461165822, TradeFee: 78935, WithdrawFee: 2317416, AdminFee: 1198175},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &StableSwap{Amp: tt.amp, Fees: testFees}
+			got, err := s.WithdrawOne(tt.amount, tt.supply, tt.base, tt.quote)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.want.PoolTokenAmount = tt.amount
+			if *got != tt.want {
+				t.Errorf("WithdrawOne(%d, %d, %d, %d) = %+v, want %+v", tt.amount, tt.supply, tt.base, tt.quote, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithdrawOneErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		amp    uint64
+		amount uint64
+		supply uint64
+		base   uint64
+		quote  uint64
+	}{
+		{"zero amp", 0, 100, 2_000_000_000, 1_000_000_000, 1_000_000_000},
+		{"empty pool", 100, 100, 0, 0, 0},
+		{"zero reserve", 100, 100, 2_000_000_000, 1_000_000_000, 0},
+		{"over supply", 100, 3, 2, 1_000_000_000, 1_000_000_000},
+		{"nothing to withdraw", 100, 0, 2_000_000_000, 1_000_000_000, 1_000_000_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &StableSwap{Amp: tt.amp, Fees: testFees}
+			if _, err := s.WithdrawOne(tt.amount, tt.supply, tt.base, tt.quote); err == nil {
+				t.Error("WithdrawOne succeeded, want error")
+			}
+		})
+	}
+}